@@ -4,20 +4,14 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // GetSystemAdmins fetches information of all system admins present in GoCD server.
 func (conf *client) GetSystemAdmins() (SystemAdmins, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return SystemAdmins{}, err
-	}
-
 	var adminsConf SystemAdmins
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -34,6 +28,8 @@ func (conf *client) GetSystemAdmins() (SystemAdmins, error) {
 		return adminsConf, &errors.MarshalError{Err: err}
 	}
 
+	adminsConf.ETAG = resp.Header().Get("ETag")
+
 	return adminsConf, nil
 }
 
@@ -41,17 +37,13 @@ func (conf *client) GetSystemAdmins() (SystemAdmins, error) {
 func (conf *client) UpdateSystemAdmins(data SystemAdmins) (SystemAdmins, error) {
 	var admins SystemAdmins
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return admins, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
 			"If-Match":     data.ETAG,
 		}).
+		SetBody(data).
 		Put(SystemAdminEndpoint)
 	if err != nil {
 		return admins, &errors.APIError{Err: err, Message: "update system admin"}
@@ -74,12 +66,7 @@ func (conf *client) UpdateSystemAdmins(data SystemAdmins) (SystemAdmins, error)
 func (conf *client) UpdateSystemAdminsBulk(data Operations) (SystemAdmins, error) {
 	var admins SystemAdmins
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return admins, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -2,7 +2,9 @@ package gocd_test
 
 import (
 	_ "embed"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/nikhilsbhat/gocd-sdk-go"
@@ -104,6 +106,41 @@ func Test_client_UpdateSystemAdmins(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("should send the system admins as the body of the update", func(t *testing.T) {
+		var received gocd.SystemAdmins
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodPut {
+				writer.WriteHeader(http.StatusMethodNotAllowed)
+
+				return
+			}
+
+			if err := json.NewDecoder(req.Body).Decode(&received); err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			writer.Header().Set("ETag", updatedEtag)
+			_, _ = writer.Write([]byte(systemAdmins))
+		}))
+		defer server.Close()
+
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		users := gocd.SystemAdmins{
+			Roles: []string{"manager"},
+			Users: []string{"john", "maria"},
+			ETAG:  "cbc5f2d5b9c13a2cc1b1efb3d8a6155d",
+		}
+
+		_, err := client.UpdateSystemAdmins(users)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"manager"}, received.Roles)
+		assert.Equal(t, []string{"john", "maria"}, received.Users)
+	})
+
 	t.Run("should error out while updating system admins due to wrong headers", func(t *testing.T) {
 		server := mockServer([]byte(systemAdmins), http.StatusOK, map[string]string{
 			"Accept":       gocd.HeaderVersionThree,
@@ -5,21 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strconv"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // GetAgents implements method that fetches the details of all the agents present in GoCD server.
 func (conf *client) GetAgents() ([]Agent, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var agentsConf AgentsConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionSeven,
 		}).
@@ -41,14 +36,9 @@ func (conf *client) GetAgents() ([]Agent, error) {
 
 // GetAgent implements method that fetches the details of a specific agent present in GoCD server.
 func (conf *client) GetAgent(agentID string) (Agent, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Agent{}, err
-	}
-
 	var agentConf Agent
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionSeven,
 		}).
@@ -70,14 +60,9 @@ func (conf *client) GetAgent(agentID string) (Agent, error) {
 
 // GetAgentJobRunHistory implements method that fetches job run history from selected agents.
 func (conf *client) GetAgentJobRunHistory(agentID string) (AgentJobHistory, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return AgentJobHistory{}, err
-	}
-
 	var jobHistoryConf AgentJobHistory
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -100,12 +85,7 @@ func (conf *client) GetAgentJobRunHistory(agentID string) (AgentJobHistory, erro
 
 // UpdateAgent updates specific agent with updated configuration passed.
 func (conf *client) UpdateAgent(agent Agent) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionSeven,
 			"Content-Type": ContentJSON,
@@ -125,12 +105,7 @@ func (conf *client) UpdateAgent(agent Agent) error {
 
 // UpdateAgentBulk will bulk update the specified agents with updated configurations.
 func (conf *client) UpdateAgentBulk(agent Agent) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionSeven,
 			"Content-Type": ContentJSON,
@@ -150,12 +125,7 @@ func (conf *client) UpdateAgentBulk(agent Agent) error {
 
 // DeleteAgent deletes the specified agent.
 func (conf *client) DeleteAgent(agentID string) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionSeven,
 		}).
@@ -173,12 +143,7 @@ func (conf *client) DeleteAgent(agentID string) (string, error) {
 
 // DeleteAgentBulk bulk deletes the specified agents.
 func (conf *client) DeleteAgentBulk(agent Agent) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionSeven,
 		}).
@@ -197,12 +162,7 @@ func (conf *client) DeleteAgentBulk(agent Agent) (string, error) {
 
 // AgentKillTask will kill running tasks from an selected agent.
 func (conf *client) AgentKillTask(agent Agent) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionSeven,
 			HeaderConfirm: "true",
@@ -218,3 +178,42 @@ func (conf *client) AgentKillTask(agent Agent) error {
 
 	return nil
 }
+
+// getAgentJobRunHistoryPage fetches a page of job run history of the agent along with the offset of the next page,
+// offset would be empty when it is the last page.
+func (conf *client) getAgentJobRunHistoryPage(agentID, pageSize, offset string) ([]JobRunHistory, string, error) {
+	if len(offset) == 0 {
+		offset = "0"
+	}
+
+	var jobHistoryConf AgentJobHistory
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionOne,
+		}).
+		SetQueryParams(map[string]string{
+			"sort_order": "DESC",
+			"page_size":  pageSize,
+			"offset":     offset,
+		}).
+		Get(fmt.Sprintf(JobRunHistoryEndpoint, agentID))
+	if err != nil {
+		return nil, "", &errors.APIError{Err: err, Message: "get agent job run history"}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, "", &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &jobHistoryConf); err != nil {
+		return nil, "", &errors.MarshalError{Err: err}
+	}
+
+	pagination := jobHistoryConf.Pagination
+	if nextOffset := pagination.Offset + int64(len(jobHistoryConf.Jobs)); nextOffset < pagination.Total {
+		return jobHistoryConf.Jobs, strconv.FormatInt(nextOffset, 10), nil
+	}
+
+	return jobHistoryConf.Jobs, "", nil
+}
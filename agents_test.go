@@ -229,6 +229,13 @@ func Test_client_GetAgentJobRunHistory1(t *testing.T) {
 					StageCounter:    1,
 					PipelineCounter: 5282,
 					Result:          "Unknown",
+					JobStateTransitions: []gocd.JobRunStateTransition{
+						{State: "Scheduled", StateChangeTime: "2019-11-12T00:20:56Z"},
+						{State: "Assigned", StateChangeTime: "2019-11-12T00:21:06Z"},
+						{State: "Preparing", StateChangeTime: "2019-11-12T00:21:17Z"},
+						{State: "Building", StateChangeTime: "2019-11-12T00:22:19Z"},
+						{State: "Rescheduled", StateChangeTime: "2019-11-12T00:37:42Z"},
+					},
 				},
 			},
 			Pagination: gocd.Pagination{
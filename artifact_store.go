@@ -5,19 +5,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetArtifactStores() (ArtifactStoresConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return ArtifactStoresConfig{}, err
-	}
-
 	var storeCfg ArtifactStoresConfigs
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -40,14 +34,9 @@ func (conf *client) GetArtifactStores() (ArtifactStoresConfig, error) {
 }
 
 func (conf *client) GetArtifactStore(name string) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var storeCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -70,14 +59,9 @@ func (conf *client) GetArtifactStore(name string) (CommonConfig, error) {
 }
 
 func (conf *client) CreateArtifactStore(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var storeCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -102,14 +86,9 @@ func (conf *client) CreateArtifactStore(config CommonConfig) (CommonConfig, erro
 }
 
 func (conf *client) UpdateArtifactStore(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var storeCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -135,12 +114,7 @@ func (conf *client) UpdateArtifactStore(config CommonConfig) (CommonConfig, erro
 }
 
 func (conf *client) DeleteArtifactStore(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
@@ -14,12 +13,7 @@ import (
 func (conf *client) GetAuthConfigs() ([]CommonConfig, error) {
 	var auth AuthConfigs
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -43,12 +37,7 @@ func (conf *client) GetAuthConfigs() ([]CommonConfig, error) {
 func (conf *client) GetAuthConfig(name string) (CommonConfig, error) {
 	var auth CommonConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return auth, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -74,12 +63,7 @@ func (conf *client) GetAuthConfig(name string) (CommonConfig, error) {
 func (conf *client) CreateAuthConfig(config CommonConfig) (CommonConfig, error) {
 	var auth CommonConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return auth, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -107,12 +91,7 @@ func (conf *client) CreateAuthConfig(config CommonConfig) (CommonConfig, error)
 func (conf *client) UpdateAuthConfig(config CommonConfig) (CommonConfig, error) {
 	var auth CommonConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return auth, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -139,12 +118,7 @@ func (conf *client) UpdateAuthConfig(config CommonConfig) (CommonConfig, error)
 
 // DeleteAuthConfig deletes the specified authorization configuration.
 func (conf *client) DeleteAuthConfig(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
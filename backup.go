@@ -5,20 +5,14 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // GetBackupConfig fetches information of backup configured in GoCD server.
 func (conf *client) GetBackupConfig() (BackupConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return BackupConfig{}, err
-	}
-
 	var backUpConf BackupConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -40,12 +34,7 @@ func (conf *client) GetBackupConfig() (BackupConfig, error) {
 
 // CreateOrUpdateBackupConfig will either create or update the config repo, it creates one if not created else update the existing with newer configuration.
 func (conf *client) CreateOrUpdateBackupConfig(backup BackupConfig) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -65,12 +54,7 @@ func (conf *client) CreateOrUpdateBackupConfig(backup BackupConfig) error {
 
 // DeleteBackupConfig deletes the backup config configured in GoCD.
 func (conf *client) DeleteBackupConfig() error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -88,14 +72,9 @@ func (conf *client) DeleteBackupConfig() error {
 
 // GetBackup gets the information of the backup which was taken earlier.
 func (conf *client) GetBackup(backupID string) (BackupStats, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return BackupStats{}, err
-	}
-
 	var backUpStats BackupStats
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -118,12 +97,7 @@ func (conf *client) GetBackup(backupID string) (BackupStats, error) {
 func (conf *client) ScheduleBackup() (map[string]string, error) {
 	var backupStats map[string]string
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return backupStats, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionTwo,
 			HeaderConfirm: "true",
@@ -0,0 +1,77 @@
+package gocd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+)
+
+func benchmarkServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(agentsJSON))
+	}))
+}
+
+// BenchmarkGetAgents measures a call made through the client shared by the calls, compare the runs of it
+// before and after a change using benchstat.
+func BenchmarkGetAgents(b *testing.B) {
+	server := benchmarkServer()
+	defer server.Close()
+
+	client, err := gocd.New(server.URL, gocd.WithAuth(gocd.Auth{NoAuth: true}))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err = client.GetAgents(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetAgentsParallel(b *testing.B) {
+	server := benchmarkServer()
+	defer server.Close()
+
+	client, err := gocd.New(server.URL, gocd.WithAuth(gocd.Auth{NoAuth: true}))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, agentsErr := client.GetAgents(); agentsErr != nil {
+				b.Error(agentsErr)
+			}
+		}
+	})
+}
+
+// BenchmarkGetAgentsWithRequestOptions measures a call made with the per-call overrides, which copy only the request options.
+func BenchmarkGetAgentsWithRequestOptions(b *testing.B) {
+	server := benchmarkServer()
+	defer server.Close()
+
+	client, err := gocd.New(server.URL, gocd.WithAuth(gocd.Auth{NoAuth: true}))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err = client.WithRequestOptions(gocd.WithHeader("X-Request-ID", "bench"), gocd.WithMaxRetries(0)).GetAgents(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package gocd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// cacheMethodKey is the key of the context holding the name of the method of GoCd making the call, set when caching.
+type cacheMethodKey struct{}
+
+// cacheDependents are the endpoints whose responses are affected by the writes to other endpoints,
+// e.g. the pipeline groups list the pipelines in them.
+var cacheDependents = map[string][]string{
+	PipelineConfigEndpoint: {PipelineGroupEndpoint},
+}
+
+// cacheEntry is a response cached, served without asking GoCD until it expires and revalidated using its ETag after.
+type cacheEntry struct {
+	path    string
+	header  http.Header
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// responseCache caches the responses of the GET calls made by the methods of GoCd having a TTL.
+type responseCache struct {
+	mutex   sync.Mutex
+	ttls    map[string]time.Duration
+	entries map[string]*cacheEntry
+}
+
+// DefaultCacheTTLs returns the TTLs for the methods of GoCd returning the data that rarely changes, to be passed
+// to WithCache, it could be modified to add or drop the methods cached.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"GetPluginsInfo":    defaultLongCacheTTL * time.Minute,
+		"GetPluginInfo":     defaultLongCacheTTL * time.Minute,
+		"GetVersionInfo":    defaultLongCacheTTL * time.Minute,
+		"GetPipelineGroups": defaultCacheTTL * time.Minute,
+		"GetRoles":          defaultCacheTTL * time.Minute,
+		"GetRole":           defaultCacheTTL * time.Minute,
+		"GetRolesByType":    defaultCacheTTL * time.Minute,
+	}
+}
+
+// WithCache caches in memory the responses of the methods of GoCd passed, keyed by their names, for the TTL set against
+// each of them. Once the TTL passes the response is revalidated with GoCD using its ETag, which responds with 304 when
+// unchanged. The responses cached are dropped once the calls modifying them, like UpdateRole, are made through the client.
+func WithCache(ttls map[string]time.Duration) Option {
+	return func(opts *options) error {
+		for method, ttl := range ttls {
+			if ttl < 0 {
+				return &errors.GoCDSDKError{Message: "TTL of the responses of '" + method + "' to be cached cannot be negative"}
+			}
+		}
+
+		opts.cacheTTLs = maps.Clone(ttls)
+
+		return nil
+	}
+}
+
+// setCache wraps the transport of the resty client to serve the responses from the cache, nothing is done when no TTL is set.
+func setCache(httpClient *resty.Client, ttls map[string]time.Duration) {
+	if len(ttls) == 0 {
+		return
+	}
+
+	httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if req.Method == http.MethodGet {
+			req.SetContext(context.WithValue(req.Context(), cacheMethodKey{}, callerMethod()))
+		}
+
+		return nil
+	})
+
+	next := httpClient.GetClient().Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	httpClient.SetTransport(&cachingTransport{
+		next:  next,
+		cache: &responseCache{ttls: ttls, entries: make(map[string]*cacheEntry)},
+	})
+}
+
+// cachingTransport serves the GET calls from the cache when possible and drops the responses cached on writes.
+type cachingTransport struct {
+	next  http.RoundTripper
+	cache *responseCache
+}
+
+func (transport *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := transport.next.RoundTrip(req)
+		if err == nil && (resp.StatusCode < http.StatusBadRequest || resp.StatusCode == http.StatusPreconditionFailed) {
+			// 412 tells that the object was modified by someone else, hence the response cached is stale as well.
+			transport.cache.invalidate(req.URL.Path)
+		}
+
+		return resp, err
+	}
+
+	method, _ := req.Context().Value(cacheMethodKey{}).(string)
+
+	ttl, ok := transport.cache.ttls[method]
+	if !ok {
+		return transport.next.RoundTrip(req)
+	}
+
+	key := req.URL.String() + "\x00" + req.Header.Get("Accept")
+
+	entry := transport.cache.get(key)
+	if entry != nil && time.Now().Before(entry.expires) {
+		return entry.response(req), nil
+	}
+
+	if entry != nil && len(entry.etag) != 0 {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := transport.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		return transport.cache.refresh(key, entry, ttl).response(req), nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		transport.cache.set(key, &cacheEntry{
+			path:    req.URL.Path,
+			header:  resp.Header.Clone(),
+			body:    body,
+			etag:    resp.Header.Get("ETag"),
+			expires: time.Now().Add(ttl),
+		})
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
+
+func (cache *responseCache) get(key string) *cacheEntry {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.entries[key]
+}
+
+func (cache *responseCache) set(key string, entry *cacheEntry) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries[key] = entry
+}
+
+// refresh stores a copy of the entry revalidated with GoCD, expiring after the TTL from now.
+func (cache *responseCache) refresh(key string, entry *cacheEntry, ttl time.Duration) *cacheEntry {
+	refreshed := *entry
+	refreshed.expires = time.Now().Add(ttl)
+
+	cache.set(key, &refreshed)
+
+	return &refreshed
+}
+
+// invalidate drops the responses of the endpoints affected by the write made to the path, which are the endpoint
+// itself, the ones it is part of, like the list of roles for a role, the ones part of it and its dependents.
+func (cache *responseCache) invalidate(path string) {
+	paths := []string{path}
+
+	for endpoint, dependents := range cacheDependents {
+		if !strings.Contains(path, endpoint) {
+			continue
+		}
+
+		base := path[:strings.Index(path, endpoint)]
+		for _, dependent := range dependents {
+			paths = append(paths, base+dependent)
+		}
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for key, entry := range cache.entries {
+		for _, affected := range paths {
+			if isPathWithin(entry.path, affected) || isPathWithin(affected, entry.path) {
+				delete(cache.entries, key)
+
+				break
+			}
+		}
+	}
+}
+
+// response returns the response cached as if it was just received for the request.
+func (entry *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}
+}
+
+// isPathWithin reports whether the path is under the parent path or the same as it.
+func isPathWithin(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, strings.TrimSuffix(parent, "/")+"/")
+}
@@ -0,0 +1,111 @@
+package gocd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// etagServer serves the roles with an ETag, responding with 304 when it matches, recording the calls it served.
+func etagServer(gets, revalidations *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			_, _ = writer.Write([]byte(`{}`))
+
+			return
+		}
+
+		gets.Add(1)
+
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			revalidations.Add(1)
+			writer.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		writer.Header().Set("ETag", `"v1"`)
+		_, _ = writer.Write([]byte(`{"_embedded": {"roles": [{"name": "admins", "type": "gocd"}]}}`))
+	}))
+}
+
+func TestWithCache(t *testing.T) {
+	t.Run("should serve the responses from the cache until the TTL passes", func(t *testing.T) {
+		var gets, revalidations atomic.Int32
+
+		server := etagServer(&gets, &revalidations)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithCache(map[string]time.Duration{"GetRoles": time.Hour}))
+		require.NoError(t, err)
+
+		first, err := client.GetRoles()
+		require.NoError(t, err)
+
+		second, err := client.GetRoles()
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, "admins", second.Role[0].Name)
+		assert.Equal(t, int32(1), gets.Load())
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), gets.Load())
+	})
+
+	t.Run("should revalidate the responses cached using the ETag once the TTL passes", func(t *testing.T) {
+		var gets, revalidations atomic.Int32
+
+		server := etagServer(&gets, &revalidations)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithCache(map[string]time.Duration{"GetRoles": 0}))
+		require.NoError(t, err)
+
+		first, err := client.GetRoles()
+		require.NoError(t, err)
+
+		second, err := client.GetRoles()
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, int32(2), gets.Load())
+		assert.Equal(t, int32(1), revalidations.Load())
+	})
+
+	t.Run("should drop the responses cached once the calls modifying them succeed", func(t *testing.T) {
+		var gets, revalidations atomic.Int32
+
+		server := etagServer(&gets, &revalidations)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithCache(gocd.DefaultCacheTTLs()))
+		require.NoError(t, err)
+
+		_, err = client.GetRoles()
+		require.NoError(t, err)
+
+		require.NoError(t, client.DeleteRole("admins"))
+
+		_, err = client.GetRoles()
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), gets.Load())
+		assert.Equal(t, int32(0), revalidations.Load())
+	})
+
+	t.Run("should error out when the TTL is negative", func(t *testing.T) {
+		_, err := gocd.New("http://localhost:8156/go", gocd.WithCache(map[string]time.Duration{"GetRoles": -time.Second}))
+		require.EqualError(t, err, "TTL of the responses of 'GetRoles' to be cached cannot be negative")
+	})
+}
@@ -4,19 +4,13 @@ import (
 	"encoding/xml"
 	"net/http"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetCCTray() ([]Project, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var projectsConf Projects
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		Get("cctray.xml")
 	if err != nil {
 		return nil, &errors.APIError{Err: err, Message: "get cctray"}
@@ -9,7 +9,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
@@ -20,14 +19,9 @@ type PipelineFiles struct {
 
 // GetConfigRepo fetches information of a specific config-repo from GoCD server.
 func (conf *client) GetConfigRepo(repo string) (ConfigRepo, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return ConfigRepo{}, err
-	}
-
 	var repoConf ConfigRepo
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionFour,
 		}).
@@ -55,14 +49,9 @@ func (conf *client) GetConfigRepo(repo string) (ConfigRepo, error) {
 
 // GetConfigRepos fetches information of all config-repos from GoCD server.
 func (conf *client) GetConfigRepos() ([]ConfigRepo, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var reposConf ConfigRepoConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionFour,
 		}).
@@ -85,14 +74,9 @@ func (conf *client) GetConfigRepos() ([]ConfigRepo, error) {
 // GetConfigReposInternal fetches information about all config repos from the GoCD server using GoCD's internal API.
 // Use GetConfigRepos for fetching all config-repos information; use this only if you know why it is being used.
 func (conf *client) GetConfigReposInternal() ([]ConfigRepo, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var reposConf ConfigRepoConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionFour,
 		}).
@@ -114,14 +98,9 @@ func (conf *client) GetConfigReposInternal() ([]ConfigRepo, error) {
 
 // GetConfigRepoDefinitions fetches information of a specific config-repo from GoCD server.
 func (conf *client) GetConfigRepoDefinitions(repo string) (ConfigRepo, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return ConfigRepo{}, err
-	}
-
 	var repoConf ConfigRepo
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionFour,
 		}).
@@ -143,12 +122,7 @@ func (conf *client) GetConfigRepoDefinitions(repo string) (ConfigRepo, error) {
 
 // CreateConfigRepo fetches information of all config-repos in GoCD server.
 func (conf *client) CreateConfigRepo(repoObj ConfigRepo) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionFour,
 			"Content-Type": ContentJSON,
@@ -168,12 +142,7 @@ func (conf *client) CreateConfigRepo(repoObj ConfigRepo) error {
 
 // UpdateConfigRepo updates the config repo configurations with the latest configurations provided.
 func (conf *client) UpdateConfigRepo(repo ConfigRepo) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionFour,
 			"Content-Type": ContentJSON,
@@ -194,12 +163,7 @@ func (conf *client) UpdateConfigRepo(repo ConfigRepo) (string, error) {
 
 // DeleteConfigRepo deletes a specific config repo.
 func (conf *client) DeleteConfigRepo(repo string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionFour,
 			"Content-Type": ContentJSON,
@@ -218,12 +182,7 @@ func (conf *client) DeleteConfigRepo(repo string) error {
 
 // ConfigRepoTriggerUpdate triggers config repo update for a specific config-repo.
 func (conf *client) ConfigRepoTriggerUpdate(name string) (map[string]string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionFour,
 			HeaderConfirm: "true",
@@ -247,12 +206,7 @@ func (conf *client) ConfigRepoTriggerUpdate(name string) (map[string]string, err
 
 // ConfigRepoStatus fetches the latest available status of the specified config repo.
 func (conf *client) ConfigRepoStatus(repo string) (map[string]bool, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionFour,
 		}).
@@ -277,12 +231,7 @@ func (conf *client) ConfigRepoStatus(repo string) (map[string]bool, error) {
 // ConfigRepoPreflightCheck runs the pre-flight checks on the config-repo with the provided pipeline files.
 // Checks posted definition file(s) for syntax and merge errors without updating the current GoCD configuration.
 func (conf *client) ConfigRepoPreflightCheck(pipelines map[string]string, pluginID string, repoID string) (bool, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return false, err
-	}
-
-	request := newClient.httpClient.R().
+	request := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
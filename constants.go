@@ -25,6 +25,7 @@ const (
 	PipelinesEndpoint           = "/api/pipelines"
 	PipelineConfigEndpoint      = "/api/admin/pipelines"
 	PipelineExportEndpoint      = "/api/admin/export/pipelines"
+	TemplatesEndpoint           = "/api/admin/templates"
 	HealthEndpoint              = "/api/v1/health"
 	DefaultTimeoutEndpoint      = "/api/admin/config/server/default_job_timeout"
 	MailServerConfigEndpoint    = "/api/config/mailserver"
@@ -50,11 +51,14 @@ const (
 	CurrentUserEndpoint         = "/api/current_user"
 	PermissionsEndpoint         = "/api/auth/permissions"
 	VSMEndpoint                 = "/pipelines/value_stream_map"
+	ArtifactFilesEndpoint       = "/files"
+	DashboardEndpoint           = "/api/dashboard"
 	HeaderVersionZero           = "application/vnd.go.cd+json"
 	HeaderVersionOne            = "application/vnd.go.cd.v1+json"
 	HeaderVersionTwo            = "application/vnd.go.cd.v2+json"
 	HeaderVersionThree          = "application/vnd.go.cd.v3+json"
 	HeaderVersionFour           = "application/vnd.go.cd.v4+json"
+	HeaderVersionSix            = "application/vnd.go.cd.v6+json"
 	HeaderVersionSeven          = "application/vnd.go.cd.v7+json"
 	HeaderVersionEleven         = "application/vnd.go.cd.v11+json"
 )
@@ -66,4 +70,18 @@ const (
 	PipelinePrefix    = "/go/api/feed/pipelines/"
 	PipelineSuffix    = "/stages.xml"
 	LocationHeader    = "Location"
+	// ServerConfigBundleVersion is the version of ServerConfigBundle exported, only the bundles of this version can be imported.
+	ServerConfigBundleVersion = 1
+)
+
+const (
+	consoleLogPath      = "cruise-output/console.log"
+	jobStateScheduled   = "Scheduled"
+	jobStateCompleted   = "Completed"
+	jobResultPassed     = "Passed"
+	stageResultPassed   = "Passed"
+	stageResultUnknown  = "Unknown"
+	stageApprovalManual = "manual"
+	// environmentOriginConfigRepo is the type of origin of the environments defined in config repos.
+	environmentOriginConfigRepo = "config_repo"
 )
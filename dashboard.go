@@ -0,0 +1,93 @@
+package gocd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// GetDashboard fetches the pipeline groups along with the latest instances of their pipelines and the status of its stages.
+// When viewName is set, the dashboard is filtered to the pipelines of the specified personalization view of the user.
+func (conf *client) GetDashboard(viewName string) (Dashboard, error) {
+	type dashboardPipelineInstance struct {
+		DashboardPipelineInstance
+		Embedded struct {
+			Stages []DashboardStage `json:"stages,omitempty"`
+		} `json:"_embedded,omitempty"`
+	}
+
+	type dashboardPipeline struct {
+		DashboardPipeline
+		Embedded struct {
+			Instances []dashboardPipelineInstance `json:"instances,omitempty"`
+		} `json:"_embedded,omitempty"`
+	}
+
+	type dashboardPipelineGroup struct {
+		Name          string   `json:"name,omitempty"`
+		CanAdminister bool     `json:"can_administer,omitempty"`
+		Pipelines     []string `json:"pipelines,omitempty"`
+	}
+
+	type dashboard struct {
+		Personalization string `json:"_personalization,omitempty"`
+		Embedded        struct {
+			PipelineGroups []dashboardPipelineGroup `json:"pipeline_groups,omitempty"`
+			Pipelines      []dashboardPipeline      `json:"pipelines,omitempty"`
+		} `json:"_embedded,omitempty"`
+	}
+
+	queryParams := make(map[string]string)
+	if len(viewName) != 0 {
+		queryParams["viewName"] = viewName
+	}
+
+	var dashboardCfg dashboard
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionFour,
+		}).
+		SetQueryParams(queryParams).
+		Get(DashboardEndpoint)
+	if err != nil {
+		return Dashboard{}, &errors.APIError{Err: err, Message: "get dashboard"}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return Dashboard{}, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &dashboardCfg); err != nil {
+		return Dashboard{}, &errors.MarshalError{Err: err}
+	}
+
+	pipelines := make(map[string]DashboardPipeline, len(dashboardCfg.Embedded.Pipelines))
+
+	for _, pipelineCfg := range dashboardCfg.Embedded.Pipelines {
+		pipeline := pipelineCfg.DashboardPipeline
+		for _, instanceCfg := range pipelineCfg.Embedded.Instances {
+			instance := instanceCfg.DashboardPipelineInstance
+			instance.Stages = instanceCfg.Embedded.Stages
+			pipeline.Instances = append(pipeline.Instances, instance)
+		}
+
+		pipelines[pipeline.Name] = pipeline
+	}
+
+	groups := make([]DashboardPipelineGroup, 0, len(dashboardCfg.Embedded.PipelineGroups))
+
+	for _, groupCfg := range dashboardCfg.Embedded.PipelineGroups {
+		group := DashboardPipelineGroup{Name: groupCfg.Name, CanAdminister: groupCfg.CanAdminister}
+		for _, pipelineName := range groupCfg.Pipelines {
+			if pipeline, ok := pipelines[pipelineName]; ok {
+				group.Pipelines = append(group.Pipelines, pipeline)
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	return Dashboard{Personalization: dashboardCfg.Personalization, PipelineGroups: groups}, nil
+}
@@ -0,0 +1,101 @@
+package gocd_test
+
+import (
+	_ "embed"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed internal/fixtures/dashboard.json
+var dashboardJSON string
+
+func Test_client_GetDashboard(t *testing.T) {
+	correctDashboardHeader := map[string]string{"Accept": gocd.HeaderVersionFour}
+
+	t.Run("should be able to fetch the dashboard with pipelines grouped under their pipeline groups", func(t *testing.T) {
+		server := mockServer([]byte(dashboardJSON), http.StatusOK, correctDashboardHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		pipeline := gocd.DashboardPipeline{
+			Name:                 "up42",
+			LastUpdatedTimestamp: 1510299695473,
+			CanPause:             true,
+			CanAdminister:        true,
+			CanUnlock:            true,
+			CanOperate:           true,
+			Instances: []gocd.DashboardPipelineInstance{
+				{
+					Label:       "1",
+					Counter:     1,
+					TriggeredBy: "changes",
+					ScheduledAt: "2017-11-10T07:25:28.539Z",
+					Stages: []gocd.DashboardStage{
+						{
+							Name:        "up42_stage",
+							Counter:     "1",
+							Status:      "Failed",
+							ApprovedBy:  "changes",
+							ScheduledAt: "2017-11-10T07:25:28.539Z",
+						},
+					},
+				},
+			},
+		}
+		pipeline.PauseInfo.Paused = true
+		pipeline.PauseInfo.PausedBy = "admin"
+		pipeline.PauseInfo.PauseReason = "under maintenance"
+
+		expected := gocd.Dashboard{
+			Personalization: "8fb4a9e0b7d3d6a5c9a53a0be7b3e0b4",
+			PipelineGroups: []gocd.DashboardPipelineGroup{
+				{Name: "first", CanAdminister: true, Pipelines: []gocd.DashboardPipeline{pipeline}},
+				{Name: "second"},
+			},
+		}
+
+		actual, err := client.GetDashboard("")
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should filter the dashboard by the personalization view", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.URL.Query().Get("viewName") != "infra" {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{"_embedded": {"pipeline_groups": [{"name": "infra", "pipelines": []}], "pipelines": []}}`))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetDashboard("infra")
+		require.NoError(t, err)
+		assert.Equal(t, gocd.Dashboard{PipelineGroups: []gocd.DashboardPipelineGroup{{Name: "infra"}}}, actual)
+	})
+
+	t.Run("should error out while fetching dashboard as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("dashboardJSON"), http.StatusBadGateway, correctDashboardHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetDashboard("")
+		require.EqualError(t, err, "got 502 from GoCD while making GET call for "+server.URL+
+			"/api/dashboard\nwith BODY:dashboardJSON")
+		assert.Equal(t, gocd.Dashboard{}, actual)
+	})
+
+	t.Run("should error out while fetching dashboard as server returned malformed response", func(t *testing.T) {
+		server := mockServer([]byte(`{"_embedded"}`), http.StatusOK, correctDashboardHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetDashboard("")
+		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
+		assert.Equal(t, gocd.Dashboard{}, actual)
+	})
+}
@@ -7,20 +7,14 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // GetEnvironments fetches information of backup configured in GoCD server.
 func (conf *client) GetEnvironments() ([]Environment, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var envConf EnvironmentInfo
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -44,12 +38,7 @@ func (conf *client) GetEnvironments() ([]Environment, error) {
 func (conf *client) GetEnvironment(name string) (Environment, error) {
 	var env Environment
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return env, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -73,12 +62,7 @@ func (conf *client) GetEnvironment(name string) (Environment, error) {
 
 // CreateEnvironment creates GoCD environment with the specified configurations.
 func (conf *client) CreateEnvironment(environment Environment) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -102,12 +86,7 @@ func (conf *client) PatchEnvironment(environment any) (Environment, error) {
 
 	var env Environment
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return env, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -133,12 +112,7 @@ func (conf *client) PatchEnvironment(environment any) (Environment, error) {
 func (conf *client) UpdateEnvironment(environment Environment) (Environment, error) {
 	var env Environment
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return env, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -165,12 +139,7 @@ func (conf *client) UpdateEnvironment(environment Environment) (Environment, err
 
 // DeleteEnvironment deletes the specified GoCD environment.
 func (conf *client) DeleteEnvironment(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).Delete(filepath.Join(EnvironmentEndpoint, name))
@@ -188,12 +157,7 @@ func (conf *client) DeleteEnvironment(name string) error {
 func (conf *client) GetEnvironmentsMerged(names []string) ([]Environment, error) {
 	var env EnvironmentInfo
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).Get(EnvironmentInternalEndpoint)
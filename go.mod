@@ -5,7 +5,6 @@ go 1.23.4
 require (
 	github.com/go-resty/resty/v2 v2.16.5
 	github.com/imdario/mergo v0.3.13
-	github.com/jinzhu/copier v0.4.0
 	github.com/sirupsen/logrus v1.9.3
 	github.com/stretchr/testify v1.10.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -1,8 +1,12 @@
 package gocd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"io"
+	"iter"
+	"net/http"
 	"reflect"
 	"sort"
 	"time"
@@ -14,9 +18,13 @@ import (
 )
 
 // client holds resty.Client which could be used for interacting with GoCD and other information.
+// It is safe for concurrent use, as none of its methods making calls to GoCD modify it; the copies returned by
+// WithContext and WithRequestOptions carry their own context and request options while sharing the resty.Client.
 type client struct {
-	httpClient *resty.Client
-	logger     *log.Logger
+	httpClient  *resty.Client
+	logger      goCdLogger.Logger
+	ctx         context.Context
+	requestOpts requestOptions
 }
 
 // GoCd implements methods to get various information from GoCD.
@@ -24,6 +32,7 @@ type GoCd interface {
 	GetAgents() ([]Agent, error)
 	GetAgent(agentID string) (Agent, error)
 	GetAgentJobRunHistory(agent string) (AgentJobHistory, error)
+	IterateAgentJobRunHistory(agentID string, opts ...HistoryOption) iter.Seq2[JobRunHistory, error]
 	UpdateAgent(agent Agent) error
 	UpdateAgentBulk(agent Agent) error
 	DeleteAgent(id string) (string, error)
@@ -56,6 +65,7 @@ type GoCd interface {
 	UpdatePipelineGroup(group PipelineGroup) (PipelineGroup, error)
 	GetPipelineRunHistory(pipeline, pageSize string, delay time.Duration) ([]PipelineRunHistory, error)
 	GetLimitedPipelineRunHistory(pipeline, pageSize, after string) ([]PipelineRunHistory, error)
+	IteratePipelineRunHistory(pipeline string, opts ...HistoryOption) iter.Seq2[PipelineRunHistory, error]
 	GetPipelineSchedules(pipeline, start, perPage string) (PipelineSchedules, error)
 	ValidatePipelineSyntax(pluginCfg plugin.Plugin, pipelines []string, fetchVersionFromServer bool) (bool, error)
 	GetEnvironments() ([]Environment, error)
@@ -73,10 +83,12 @@ type GoCd interface {
 	ScheduleBackup() (map[string]string, error)
 	GetPipelines() (PipelinesInfo, error)
 	GetPipelineState(pipeline string) (PipelineState, error)
+	GetDashboard(viewName string) (Dashboard, error)
 	PipelinePause(name string, message any) error
 	PipelineUnPause(name string) error
 	PipelineUnlock(name string) error
 	SchedulePipeline(name string, schedule Schedule) error
+	TriggerAndWait(name string, schedule Schedule, opts ...TriggerOption) (PipelineRunResult, error)
 	GetPipelineInstance(pipeline PipelineObject) (map[string]interface{}, error)
 	CommentOnPipeline(comment PipelineObject) error
 	GetPipelineConfig(name string) (PipelineConfig, error)
@@ -87,9 +99,29 @@ type GoCd interface {
 	GetScheduledJobs() (ScheduledJobs, error)
 	RunFailedJobs(stage Stage) (string, error)
 	RunJobs(stage Stage) (string, error)
+	GetJobInstance(job JobObject) (JobInstance, error)
+	GetJobHistory(pipeline, stage, job, pageSize, after string) (JobHistory, error)
+	IterateJobHistory(pipeline, stage, job string, opts ...HistoryOption) iter.Seq2[JobInstance, error]
+	GetJobConsoleLog(job JobObject, offset int64) (ConsoleLog, error)
+	StreamJobConsoleLog(job JobObject, writer io.Writer, interval time.Duration) error
+	GetJobArtifacts(job JobObject) ([]JobArtifact, error)
+	DownloadJobArtifact(job JobObject, path string, writer io.Writer) error
+	DownloadJobArtifactDirectory(job JobObject, path string, writer io.Writer) error
+	UploadJobArtifact(job JobObject, path string, reader io.Reader) error
+	AppendJobArtifact(job JobObject, path string, reader io.Reader) error
 	RunStage(stage Stage) (string, error)
 	CancelStage(stage Stage) (string, error)
+	GetStageInstance(stage Stage) (StageInstance, error)
+	GetStageHistory(pipeline, stage, pageSize, after string) (StageHistory, error)
+	IterateStageHistory(pipeline, stage string, opts ...HistoryOption) iter.Seq2[StageInstance, error]
 	ExtractTemplatePipeline(pipeline, template string) (PipelineConfig, error)
+	GetTemplates() ([]PipelineTemplateConfig, error)
+	GetTemplate(name string) (PipelineTemplateConfig, error)
+	CreateTemplate(config PipelineTemplateConfig) (PipelineTemplateConfig, error)
+	UpdateTemplate(config PipelineTemplateConfig) (PipelineTemplateConfig, error)
+	DeleteTemplate(name string) error
+	GetTemplateAuthorization(name string) (TemplateAuthorization, error)
+	UpdateTemplateAuthorization(name string, authorization TemplateAuthorization) (TemplateAuthorization, error)
 	EncryptText(value string) (Encrypted, error)
 	DecryptText(value, cipherKey string) (string, error)
 	GetArtifactConfig() (ArtifactInfo, error)
@@ -109,6 +141,13 @@ type GoCd interface {
 	GetPluginSettings(name string) (PluginSettings, error)
 	CreatePluginSettings(settings PluginSettings) (PluginSettings, error)
 	UpdatePluginSettings(settings PluginSettings) (PluginSettings, error)
+	ExportServerConfig() (ServerConfigBundle, error)
+	ImportServerConfig(bundle ServerConfigBundle, opts ...ImportOption) error
+	ModifyPipelineConfig(name string, mutate func(config *PipelineConfig) error, opts ...ModifyOption) (PipelineConfig, error)
+	ModifyPipelineGroup(name string, mutate func(group *PipelineGroup) error, opts ...ModifyOption) (PipelineGroup, error)
+	ModifyRole(name string, mutate func(role *Role) error, opts ...ModifyOption) (Role, error)
+	ModifyEnvironment(name string, mutate func(environment *Environment) error, opts ...ModifyOption) (Environment, error)
+	ModifySystemAdmins(mutate func(admins *SystemAdmins) error, opts ...ModifyOption) (SystemAdmins, error)
 	GetClusterProfiles() (ProfilesConfig, error)
 	GetClusterProfile(name string) (CommonConfig, error)
 	CreateClusterProfile(config CommonConfig) (CommonConfig, error)
@@ -166,6 +205,8 @@ type GoCd interface {
 	GetCCTray() ([]Project, error)
 	SetRetryCount(count int)
 	SetRetryWaitTime(count int)
+	WithContext(ctx context.Context) GoCd
+	WithRequestOptions(opts ...RequestOption) GoCd
 }
 
 // Auth holds information of authorisations configurations used for GoCd.
@@ -177,38 +218,104 @@ type Auth struct {
 }
 
 // NewClient returns new instance of httpClient when invoked.
+// It falls back to skipping TLS verification when no CA is passed, use New for finer control over the client.
 func NewClient(baseURL string, auth Auth, logLevel string, caContent []byte) GoCd {
-	logger := log.New()
-	logger.SetLevel(goCdLogger.GetLoglevel(logLevel))
-	logger.WithField(goCdAPILoggerName, true)
-	logger.SetFormatter(&log.JSONFormatter{})
+	tlsConfig := &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+
+	if len(caContent) != 0 {
+		certPool := x509.NewCertPool()
+		certPool.AppendCertsFromPEM(caContent)
+		tlsConfig = &tls.Config{RootCAs: certPool} //nolint:gosec
+	}
+
+	// none of the options used here would error, hence it is safe to ignore the error.
+	goCdClient, _ := New(baseURL, WithAuth(auth), WithLogLevel(logLevel), WithTLSConfig(tlsConfig))
+
+	return goCdClient
+}
+
+// New returns new instance of httpClient configured with the passed options.
+// Unlike NewClient, TLS verification is enabled by default and has to be turned off explicitly using WithInsecureSkipVerify.
+// Both negotiate DefaultAPIVersions with the GoCD server, unless WithoutVersionNegotiation is set.
+func New(baseURL string, opts ...Option) (GoCd, error) {
+	clientOpts := &options{
+		retryPolicy: DefaultRetryPolicy(),
+		apiVersions: DefaultAPIVersions(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(clientOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := clientOpts.logger
+	if logger == nil {
+		defaultLogger := log.New()
+		defaultLogger.SetLevel(goCdLogger.GetLoglevel(clientOpts.logLevel))
+		defaultLogger.WithField(goCdAPILoggerName, true)
+		defaultLogger.SetFormatter(&log.JSONFormatter{})
+
+		logger = goCdLogger.NewLogrus(defaultLogger)
+	}
 
 	newClient := resty.New()
 	newClient.SetLogger(logger)
-	newClient.SetRetryCount(defaultRetryCount)
-	newClient.SetRetryWaitTime(defaultRetryWaitTime * time.Second)
+	setRetryPolicy(newClient, clientOpts.retryPolicy)
 
-	if logLevel == "debug" {
+	if clientOpts.logLevel == "debug" || clientOpts.debug {
 		newClient.SetDebug(true)
 	}
 
-	auth.setAuth(newClient)
+	clientOpts.auth.setAuth(newClient)
 
 	// setting authorization
 	newClient.SetBaseURL(baseURL)
 
-	if len(caContent) != 0 {
-		certPool := x509.NewCertPool()
-		certPool.AppendCertsFromPEM(caContent)
-		newClient.SetTLSClientConfig(&tls.Config{RootCAs: certPool}) //nolint:gosec
-	} else {
-		newClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	transport := clientOpts.transport
+	if httpTransport, ok := transport.(*http.Transport); ok {
+		// the transport is cloned, so that the TLS and proxy options are not applied to the one owned by the caller.
+		transport = httpTransport.Clone()
+	}
+
+	if transport != nil {
+		newClient.SetTransport(transport)
 	}
 
-	return &client{
+	if _, ok := transport.(*http.Transport); ok || transport == nil {
+		tlsConfig, err := clientOpts.getTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		newClient.SetTLSClientConfig(tlsConfig)
+	}
+
+	if clientOpts.timeout != 0 {
+		newClient.SetTimeout(clientOpts.timeout)
+	}
+
+	if len(clientOpts.proxy) != 0 {
+		newClient.SetProxy(clientOpts.proxy)
+	}
+
+	if len(clientOpts.userAgent) != 0 {
+		newClient.SetHeader("User-Agent", clientOpts.userAgent)
+	}
+
+	setRequestTimeout(newClient)
+	setHooks(newClient, clientOpts.hooks)
+	setLimits(newClient, clientOpts.limit, clientOpts.endpointLimits)
+	setCache(newClient, clientOpts.cacheTTLs)
+
+	goCdClient := &client{
 		httpClient: newClient,
 		logger:     logger,
 	}
+
+	setVersionNegotiation(newClient, goCdClient, clientOpts.apiVersions)
+
+	return goCdClient, nil
 }
 
 func (auth *Auth) setAuth(newClient *resty.Client) {
@@ -223,15 +330,38 @@ func (auth *Auth) setAuth(newClient *resty.Client) {
 }
 
 // SetRetryCount sets retry count for the go-resty client.
+//
+// Deprecated: it modifies the client shared by the calls in flight, set it using WithRetry when creating the client
+// or cap it per call using WithRequestOptions and WithMaxRetries.
 func (conf *client) SetRetryCount(count int) {
 	conf.httpClient.SetRetryCount(count)
 }
 
-// SetRetryWaitTime sets retry wait time for the go-resty client.
+// SetRetryWaitTime sets the seconds to wait before the first retry for the go-resty client.
+//
+// Deprecated: it modifies the client shared by the calls in flight, set it using WithRetry when creating the client.
 func (conf *client) SetRetryWaitTime(count int) {
 	conf.httpClient.SetRetryWaitTime(time.Duration(count) * time.Second)
 }
 
+// WithContext returns a copy of the client bound to the passed context, every call made through the returned client
+// honours cancellation and deadlines of the context. The underlying http client is shared with the original client.
+func (conf *client) WithContext(ctx context.Context) GoCd {
+	newClient := *conf
+	newClient.ctx = ctx
+
+	return &newClient
+}
+
+// getContext returns the context bound to the client, context.Background is returned when no context was bound.
+func (conf *client) getContext() context.Context {
+	if conf.ctx == nil {
+		return context.Background()
+	}
+
+	return conf.ctx
+}
+
 func GetGoCDMethodNames() []string {
 	t := reflect.TypeOf((*GoCd)(nil)).Elem()
 
@@ -1,6 +1,7 @@
 package gocd_test
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"testing"
@@ -13,9 +14,9 @@ import (
 func TestGetGoCDMethodNames(t *testing.T) {
 	t.Run("should list all method names", func(t *testing.T) {
 		response := gocd.GetGoCDMethodNames()
-		assert.Len(t, response, 145)
+		assert.Len(t, response, 178)
 		assert.Equal(t, "AgentKillTask", response[0])
-		assert.Equal(t, "UpdatePipelineGroup", response[137])
+		assert.Equal(t, "UpdatePipelineGroup", response[165])
 	})
 }
 
@@ -62,3 +63,31 @@ func TestNewClient(t *testing.T) {
 		assert.NotNil(t, client)
 	})
 }
+
+func TestClient_WithContext(t *testing.T) {
+	t.Run("should error out when the context bound to the client is already cancelled", func(t *testing.T) {
+		server := mockServer([]byte(agentsJSON), http.StatusOK,
+			map[string]string{"Accept": gocd.HeaderVersionSeven}, false, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := gocd.NewClient(server.URL, auth, "info", nil).WithContext(ctx)
+
+		actual, err := client.GetAgents()
+		require.EqualError(t, err, "call made to get agents information errored with: "+
+			"Get \""+server.URL+"/api/agents\": context canceled")
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should be able to make calls with a context bound client", func(t *testing.T) {
+		server := mockServer([]byte(agentsJSON), http.StatusOK,
+			map[string]string{"Accept": gocd.HeaderVersionSeven}, false, nil)
+
+		client := gocd.NewClient(server.URL, auth, "info", nil).WithContext(context.Background())
+
+		actual, err := client.GetAgents()
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+}
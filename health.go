@@ -4,20 +4,14 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // GetServerHealthMessages implements method that fetches the details of all warning and errors.
 func (conf *client) GetServerHealthMessages() ([]ServerHealth, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var health []ServerHealth
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -38,14 +32,9 @@ func (conf *client) GetServerHealthMessages() ([]ServerHealth, error) {
 }
 
 func (conf *client) GetServerHealth() (map[string]string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var health map[string]string
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetResult(&health).Get(HealthEndpoint)
 	if err != nil {
 		return health, &errors.APIError{Err: err, Message: "get server health"}
@@ -0,0 +1,180 @@
+package gocd
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// HistoryOption customises the iteration over the run history of pipelines, stages, jobs and agents.
+type HistoryOption func(opts *historyOptions)
+
+type historyOptions struct {
+	pageSize int
+	maxItems int
+	since    time.Time
+	delay    time.Duration
+}
+
+// WithPageSize sets the number of runs fetched from GoCD per call, defaults to 10.
+// GoCD accepts the page sizes from 10 to 100, so the size is clamped to them.
+func WithPageSize(size int) HistoryOption {
+	return func(opts *historyOptions) {
+		opts.pageSize = min(max(size, defaultHistoryPageSize), maxHistoryPageSize)
+	}
+}
+
+// WithMaxItems stops the iteration once the specified number of runs are yielded.
+func WithMaxItems(count int) HistoryOption {
+	return func(opts *historyOptions) {
+		opts.maxItems = count
+	}
+}
+
+// WithSince stops the iteration at the first run scheduled before the specified time.
+// History is returned by GoCD latest first, so no older runs are fetched after that.
+func WithSince(since time.Time) HistoryOption {
+	return func(opts *historyOptions) {
+		opts.since = since
+	}
+}
+
+// WithPageDelay sets the time to wait before fetching the next page, helps in not overloading GoCD with long histories.
+func WithPageDelay(delay time.Duration) HistoryOption {
+	return func(opts *historyOptions) {
+		opts.delay = delay
+	}
+}
+
+// IteratePipelineRunHistory lazily iterates over the run history of the pipeline, latest first.
+// Pages are fetched only as the iteration proceeds, breaking out of the loop stops further calls to GoCD.
+func (conf *client) IteratePipelineRunHistory(pipeline string, opts ...HistoryOption) iter.Seq2[PipelineRunHistory, error] {
+	return iterateHistory(conf, fmt.Sprintf("get pipeline history for '%s'", pipeline), opts,
+		func(pageSize, cursor string) ([]PipelineRunHistory, string, error) {
+			return conf.getPipelineRunHistoryPage(pipeline, pageSize, cursor)
+		},
+		func(run PipelineRunHistory) time.Time {
+			return fromUnixMilli(int64(run.ScheduledDate))
+		})
+}
+
+// IterateStageHistory lazily iterates over the run history of the stage, latest first.
+func (conf *client) IterateStageHistory(pipeline, stage string, opts ...HistoryOption) iter.Seq2[StageInstance, error] {
+	return iterateHistory(conf, fmt.Sprintf("get stage history for '%s/%s'", pipeline, stage), opts,
+		func(pageSize, cursor string) ([]StageInstance, string, error) {
+			history, err := conf.GetStageHistory(pipeline, stage, pageSize, cursor)
+
+			return history.Stages, history.Next, err
+		},
+		func(stageInstance StageInstance) time.Time {
+			return fromUnixMilli(stageInstance.ScheduledAt)
+		})
+}
+
+// IterateJobHistory lazily iterates over the run history of the job, latest first.
+func (conf *client) IterateJobHistory(pipeline, stage, job string, opts ...HistoryOption) iter.Seq2[JobInstance, error] {
+	return iterateHistory(conf, fmt.Sprintf("get job history for '%s/%s/%s'", pipeline, stage, job), opts,
+		func(pageSize, cursor string) ([]JobInstance, string, error) {
+			history, err := conf.GetJobHistory(pipeline, stage, job, pageSize, cursor)
+
+			return history.Jobs, history.Next, err
+		},
+		func(jobInstance JobInstance) time.Time {
+			return fromUnixMilli(jobInstance.ScheduledDate)
+		})
+}
+
+// IterateAgentJobRunHistory lazily iterates over the jobs run by the agent, latest first.
+func (conf *client) IterateAgentJobRunHistory(agentID string, opts ...HistoryOption) iter.Seq2[JobRunHistory, error] {
+	return iterateHistory(conf, fmt.Sprintf("get job run history of agent '%s'", agentID), opts,
+		func(pageSize, cursor string) ([]JobRunHistory, string, error) {
+			return conf.getAgentJobRunHistoryPage(agentID, pageSize, cursor)
+		},
+		JobRunHistory.scheduledAt)
+}
+
+// iterateHistory yields the items of the pages returned by fetch until the cursor to the next page is empty.
+// scheduledAt is used to identify the items older than the since bound, zero time is never considered old.
+func iterateHistory[T any](conf *client, message string, opts []HistoryOption,
+	fetch func(pageSize, cursor string) ([]T, string, error), scheduledAt func(T) time.Time,
+) iter.Seq2[T, error] {
+	options := historyOptions{pageSize: defaultHistoryPageSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(yield func(T, error) bool) {
+		var (
+			empty  T
+			cursor string
+			count  int
+		)
+
+		for {
+			items, next, err := fetch(strconv.Itoa(options.pageSize), cursor)
+			if err != nil {
+				yield(empty, err)
+
+				return
+			}
+
+			for _, item := range items {
+				if scheduled := scheduledAt(item); !options.since.IsZero() && !scheduled.IsZero() && scheduled.Before(options.since) {
+					return
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+
+				if count++; options.maxItems > 0 && count >= options.maxItems {
+					return
+				}
+			}
+
+			if len(next) == 0 || len(items) == 0 {
+				return
+			}
+
+			cursor = next
+
+			select {
+			case <-conf.getContext().Done():
+				yield(empty, &errors.APIError{Err: conf.getContext().Err(), Message: message})
+
+				return
+			case <-time.After(options.delay):
+			}
+		}
+	}
+}
+
+// scheduledAt returns the time at which the job was scheduled on the agent, zero time when it is not known.
+func (job JobRunHistory) scheduledAt() time.Time {
+	for _, transition := range job.JobStateTransitions {
+		if transition.State != jobStateScheduled {
+			continue
+		}
+
+		scheduled, err := time.Parse(time.RFC3339, transition.StateChangeTime)
+		if err != nil {
+			return time.Time{}
+		}
+
+		return scheduled
+	}
+
+	return time.Time{}
+}
+
+// fromUnixMilli converts the epoch milliseconds returned by GoCD to time, zero time is returned when it is not set.
+func fromUnixMilli(milliseconds int64) time.Time {
+	if milliseconds == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(milliseconds)
+}
@@ -0,0 +1,251 @@
+package gocd_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pipelineHistoryServer serves run history of pipeline 'pipeline1' with counters 10 to 1, two runs per page,
+// each run is scheduled an hour before the next one.
+func pipelineHistoryServer(calls *int32) *httptest.Server {
+	scheduled := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		start := 10
+		if after := req.URL.Query().Get("after"); len(after) != 0 {
+			start, _ = strconv.Atoi(after)
+		}
+
+		var pipelines string
+
+		for counter := start; counter > start-2 && counter > 0; counter-- {
+			if len(pipelines) != 0 {
+				pipelines += ","
+			}
+
+			scheduledDate := scheduled.Add(-time.Duration(10-counter) * time.Hour).UnixMilli()
+			pipelines += fmt.Sprintf(`{"name": "pipeline1", "counter": %d, "scheduled_date": %d}`, counter, scheduledDate)
+		}
+
+		links := "{}"
+		if start-2 > 0 {
+			links = fmt.Sprintf(`{"next": {"href": "http://ci.example.com/go/api/pipelines/pipeline1/history?after=%d"}}`, start-2)
+		}
+
+		_, _ = writer.Write([]byte(fmt.Sprintf(`{"_links": %s, "pipelines": [%s]}`, links, pipelines)))
+	}))
+}
+
+func Test_client_IteratePipelineRunHistory(t *testing.T) {
+	t.Run("should lazily iterate over all pages of pipeline run history", func(t *testing.T) {
+		var calls int32
+
+		server := pipelineHistoryServer(&calls)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		counters := make([]int, 0)
+
+		for run, err := range client.IteratePipelineRunHistory("pipeline1") {
+			require.NoError(t, err)
+
+			counters = append(counters, run.Counter)
+		}
+
+		assert.Equal(t, []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, counters)
+		assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should clamp the page size to the ones accepted by GoCD", func(t *testing.T) {
+		var pageSize atomic.Value
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			pageSize.Store(req.URL.Query().Get("page_size"))
+			_, _ = writer.Write([]byte(`{"pipelines": []}`))
+		}))
+		defer server.Close()
+
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		for size, expected := range map[int]string{5: "10", 50: "50", 500: "100"} {
+			for _, err := range client.IteratePipelineRunHistory("pipeline1", gocd.WithPageSize(size)) {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, expected, pageSize.Load())
+		}
+	})
+
+	t.Run("should not fetch further pages when the iteration is stopped early", func(t *testing.T) {
+		var calls int32
+
+		server := pipelineHistoryServer(&calls)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		counters := make([]int, 0)
+
+		for run, err := range client.IteratePipelineRunHistory("pipeline1") {
+			require.NoError(t, err)
+
+			counters = append(counters, run.Counter)
+			if run.Counter == 7 {
+				break
+			}
+		}
+
+		assert.Equal(t, []int{10, 9, 8, 7}, counters)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should stop the iteration once max items are yielded", func(t *testing.T) {
+		var calls int32
+
+		server := pipelineHistoryServer(&calls)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		counters := make([]int, 0)
+
+		for run, err := range client.IteratePipelineRunHistory("pipeline1", gocd.WithMaxItems(3)) {
+			require.NoError(t, err)
+
+			counters = append(counters, run.Counter)
+		}
+
+		assert.Equal(t, []int{10, 9, 8}, counters)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should stop the iteration at the first run scheduled before since", func(t *testing.T) {
+		var calls int32
+
+		server := pipelineHistoryServer(&calls)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		counters := make([]int, 0)
+		since := time.Date(2024, 1, 1, 5, 30, 0, 0, time.UTC)
+
+		for run, err := range client.IteratePipelineRunHistory("pipeline1", gocd.WithSince(since)) {
+			require.NoError(t, err)
+
+			counters = append(counters, run.Counter)
+		}
+
+		assert.Equal(t, []int{10, 9, 8, 7, 6}, counters)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should yield the error returned by GoCD and stop the iteration", func(t *testing.T) {
+		server := mockServer([]byte("pipelineRunHistoryJSON"), http.StatusBadGateway, nil, true, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		var errs []error
+
+		for _, err := range client.IteratePipelineRunHistory("pipeline1") {
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "got 502 from GoCD while making GET call for "+server.URL+
+			"/api/pipelines/pipeline1/history?page_size=10\nwith BODY:pipelineRunHistoryJSON")
+	})
+
+	t.Run("should stop the iteration when the context is cancelled while waiting for next page", func(t *testing.T) {
+		var calls int32
+
+		server := pipelineHistoryServer(&calls)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		client := gocd.NewClient(server.URL, auth, "info", nil).WithContext(ctx)
+
+		var lastErr error
+
+		for _, err := range client.IteratePipelineRunHistory("pipeline1", gocd.WithPageDelay(10*time.Second)) {
+			lastErr = err
+		}
+
+		require.EqualError(t, lastErr, "call made to get pipeline history for 'pipeline1' errored with: context deadline exceeded")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func Test_client_IterateStageHistory(t *testing.T) {
+	t.Run("should iterate over the stage history until the cursor to next page is present", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&calls, 1)
+
+			if req.URL.Query().Get("after") == "3" {
+				_, _ = writer.Write([]byte(`{"stages": [{"name": "up42_stage", "pipeline_counter": 3, "scheduled_at": 1612767015870}]}`))
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(stageHistoryJSON))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		counters := make([]int64, 0)
+
+		for stage, err := range client.IterateStageHistory("up42", "up42_stage") {
+			require.NoError(t, err)
+
+			counters = append(counters, stage.PipelineCounter)
+		}
+
+		assert.Equal(t, []int64{5, 4, 3}, counters)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func Test_client_IterateAgentJobRunHistory(t *testing.T) {
+	t.Run("should iterate over the job run history of agent using offset", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			if req.URL.Query().Get("sort_order") != "DESC" || req.URL.Query().Get("page_size") != "10" {
+				writer.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(fmt.Sprintf(`{"jobs": [{"job_name": "job%d", "job_state_transitions": [
+				{"state": "Scheduled", "state_change_time": "2019-11-1%dT00:20:56Z"}]}],
+				"pagination": {"page_size": 1, "offset": %d, "total": 3}}`, offset, 9-offset, offset)))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		jobs := make([]string, 0)
+
+		for job, err := range client.IterateAgentJobRunHistory("agent1") {
+			require.NoError(t, err)
+
+			jobs = append(jobs, job.JobName)
+		}
+
+		assert.Equal(t, []string{"job0", "job1", "job2"}, jobs)
+
+		jobs = make([]string, 0)
+		since := time.Date(2019, 11, 18, 0, 0, 0, 0, time.UTC)
+
+		for job, err := range client.IterateAgentJobRunHistory("agent1", gocd.WithSince(since)) {
+			require.NoError(t, err)
+
+			jobs = append(jobs, job.JobName)
+		}
+
+		assert.Equal(t, []string{"job0", "job1"}, jobs)
+	})
+}
@@ -0,0 +1,141 @@
+package gocd
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestInfo describes a call made to GoCD, passed to the Hooks.
+type RequestInfo struct {
+	// Method is the name of the method of GoCd that made the call, e.g. GetAgents.
+	Method     string
+	HTTPMethod string
+	// Endpoint is the path of the URL called, e.g. /go/api/agents.
+	Endpoint string
+	// Attempt is 1 for the first attempt and is incremented on every retry.
+	Attempt int
+	// StatusCode is set only in AfterResponse.
+	StatusCode int
+	// Duration is the time taken by the attempt, it is not set in BeforeRequest.
+	Duration time.Duration
+	// Err is set only in OnError.
+	Err error
+}
+
+// Hooks are called around every attempt of the calls made to GoCD, none of them are required.
+type Hooks struct {
+	// BeforeRequest is called before the call is made. The context returned is used for the call and passed to the
+	// other hooks, e.g. to carry a span; the context passed is to be returned when there is nothing to carry.
+	BeforeRequest func(ctx context.Context, info RequestInfo) context.Context
+	// AfterResponse is called when GoCD responded, irrespective of the status code.
+	AfterResponse func(ctx context.Context, info RequestInfo)
+	// OnError is called when GoCD could not be reached or the call was cancelled, so no response was received.
+	OnError func(ctx context.Context, info RequestInfo)
+}
+
+// requestInfoKey is the key of the context holding the RequestInfo of the call, set before the attempt is made.
+type requestInfoKey struct{}
+
+// clientMethodPrefix is the prefix of the names of the functions of the methods of client, as reported in call stacks.
+var clientMethodPrefix = reflect.TypeOf(client{}).PkgPath() + ".(*client)."
+
+// WithHooks sets the hooks to be called around the calls made to GoCD, it could be passed multiple times
+// to set multiple hooks, which are called in the order passed.
+func WithHooks(hooks Hooks) Option {
+	return func(opts *options) error {
+		opts.hooks = append(opts.hooks, hooks)
+
+		return nil
+	}
+}
+
+// setHooks tags each attempt with the method of GoCd making it and wraps the transport to call the hooks around it.
+func setHooks(httpClient *resty.Client, hooks []Hooks) {
+	if len(hooks) == 0 {
+		return
+	}
+
+	httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		req.SetContext(context.WithValue(req.Context(), requestInfoKey{}, RequestInfo{
+			Method:     callerMethod(),
+			HTTPMethod: req.Method,
+			Attempt:    req.Attempt,
+		}))
+
+		return nil
+	})
+
+	next := httpClient.GetClient().Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	httpClient.SetTransport(&hookedTransport{next: next, hooks: hooks})
+}
+
+// hookedTransport calls the hooks around each round trip made by the transport wrapped.
+type hookedTransport struct {
+	next  http.RoundTripper
+	hooks []Hooks
+}
+
+func (transport *hookedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	info.Endpoint = req.URL.Path
+
+	for _, hooks := range transport.hooks {
+		if hooks.BeforeRequest != nil {
+			ctx = hooks.BeforeRequest(ctx, info)
+		}
+	}
+
+	start := time.Now()
+	resp, err := transport.next.RoundTrip(req.WithContext(ctx))
+	info.Duration = time.Since(start)
+
+	for _, hooks := range transport.hooks {
+		switch {
+		case err != nil && hooks.OnError != nil:
+			info.Err = err
+			hooks.OnError(ctx, info)
+		case err == nil && hooks.AfterResponse != nil:
+			info.StatusCode = resp.StatusCode
+			hooks.AfterResponse(ctx, info)
+		}
+	}
+
+	return resp, err
+}
+
+// callerMethod returns the name of the innermost exported method of client in the call stack,
+// which is the method of GoCd that made the call.
+func callerMethod() string {
+	const maxDepth = 64
+
+	callers := make([]uintptr, maxDepth)
+	frames := runtime.CallersFrames(callers[:runtime.Callers(1, callers)])
+
+	for {
+		frame, more := frames.Next()
+
+		if method, ok := strings.CutPrefix(frame.Function, clientMethodPrefix); ok {
+			method, _, _ = strings.Cut(method, ".")
+			if len(method) != 0 && unicode.IsUpper(rune(method[0])) {
+				return method
+			}
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
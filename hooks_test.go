@@ -0,0 +1,94 @@
+package gocd_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hookKey struct{}
+
+// recordingHooks records the RequestInfo passed to each of the hooks, prefixed with the name of the hook.
+func recordingHooks(calls *[]string, infos *[]gocd.RequestInfo) gocd.Hooks {
+	return gocd.Hooks{
+		BeforeRequest: func(ctx context.Context, info gocd.RequestInfo) context.Context {
+			*calls = append(*calls, "before "+info.Method)
+
+			return context.WithValue(ctx, hookKey{}, "carried")
+		},
+		AfterResponse: func(ctx context.Context, info gocd.RequestInfo) {
+			*calls = append(*calls, "after "+info.Method+" "+ctx.Value(hookKey{}).(string))
+			*infos = append(*infos, info)
+		},
+		OnError: func(ctx context.Context, info gocd.RequestInfo) {
+			*calls = append(*calls, "error "+info.Method+" "+ctx.Value(hookKey{}).(string))
+			*infos = append(*infos, info)
+		},
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	t.Run("should call the hooks with the method of GoCd making the call", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodPut {
+				writer.WriteHeader(http.StatusPreconditionFailed)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		calls := make([]string, 0)
+		infos := make([]gocd.RequestInfo, 0)
+
+		client, err := gocd.New(server.URL, gocd.WithHooks(recordingHooks(&calls, &infos)))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+
+		_, err = client.UpdateSystemAdmins(gocd.SystemAdmins{})
+		require.Error(t, err)
+
+		assert.Equal(t, []string{
+			"before GetAgents", "after GetAgents carried",
+			"before UpdateSystemAdmins", "after UpdateSystemAdmins carried",
+		}, calls)
+
+		assert.Equal(t, http.MethodGet, infos[0].HTTPMethod)
+		assert.Equal(t, gocd.AgentsEndpoint, infos[0].Endpoint)
+		assert.Equal(t, 1, infos[0].Attempt)
+		assert.Equal(t, http.StatusOK, infos[0].StatusCode)
+		assert.Positive(t, infos[0].Duration)
+		assert.Equal(t, http.StatusPreconditionFailed, infos[1].StatusCode)
+	})
+
+	t.Run("should call the error hook on every attempt when GoCD could not be reached", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		calls := make([]string, 0)
+		infos := make([]gocd.RequestInfo, 0)
+
+		client, err := gocd.New(server.URL, gocd.WithRetry(1, time.Millisecond), gocd.WithHooks(recordingHooks(&calls, &infos)))
+		require.NoError(t, err)
+
+		_, err = client.GetPipelineGroups()
+		require.Error(t, err)
+
+		assert.Equal(t, []string{
+			"before GetPipelineGroups", "error GetPipelineGroups carried",
+			"before GetPipelineGroups", "error GetPipelineGroups carried",
+		}, calls)
+		assert.Equal(t, []int{1, 2}, []int{infos[0].Attempt, infos[1].Attempt})
+		require.Error(t, infos[1].Err)
+	})
+}
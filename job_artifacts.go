@@ -0,0 +1,127 @@
+package gocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// GetJobArtifacts fetches the tree of files and folders uploaded as artifacts by the specified run of the job.
+func (conf *client) GetJobArtifacts(job JobObject) ([]JobArtifact, error) {
+	var artifacts []JobArtifact
+
+	resp, err := conf.request().
+		Get(filepath.Join(ArtifactFilesEndpoint, job.path()) + ".json")
+	if err != nil {
+		return nil, &errors.APIError{Err: err, Message: fmt.Sprintf("get artifacts of job '%s'", job.path())}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &artifacts); err != nil {
+		return nil, &errors.MarshalError{Err: err}
+	}
+
+	return artifacts, nil
+}
+
+// DownloadJobArtifact downloads the artifact file present at the path, relative to the artifacts of the job, to the writer.
+func (conf *client) DownloadJobArtifact(job JobObject, path string, writer io.Writer) error {
+	_, err := conf.downloadJobArtifact(job, path, writer)
+
+	return err
+}
+
+// DownloadJobArtifactDirectory downloads the artifact folder present at the path, relative to the artifacts of the job,
+// as zip to the writer. GoCD takes a while to prepare the zip of larger folders, this waits until the zip is ready.
+func (conf *client) DownloadJobArtifactDirectory(job JobObject, path string, writer io.Writer) error {
+	for {
+		ready, err := conf.downloadJobArtifact(job, path+".zip", writer)
+		if err != nil || ready {
+			return err
+		}
+
+		select {
+		case <-conf.getContext().Done():
+			return &errors.APIError{Err: conf.getContext().Err(), Message: fmt.Sprintf("download artifact directory '%s' of job '%s'", path, job.path())}
+		case <-time.After(defaultArtifactZipWaitTime * time.Second):
+		}
+	}
+}
+
+// UploadJobArtifact uploads the content read from the reader as artifact file to the path, relative to the artifacts of the job.
+func (conf *client) UploadJobArtifact(job JobObject, path string, reader io.Reader) error {
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			HeaderConfirm: "true",
+		}).
+		SetFileReader("file", filepath.Base(path), reader).
+		Post(filepath.Join(ArtifactFilesEndpoint, job.path(), path))
+	if err != nil {
+		return &errors.APIError{Err: err, Message: fmt.Sprintf("upload artifact '%s' of job '%s'", path, job.path())}
+	}
+
+	if resp.StatusCode() != http.StatusCreated {
+		return &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	return nil
+}
+
+// AppendJobArtifact appends the content read from the reader to the artifact file present at the path, relative to the artifacts of the job.
+// The call is never retried, as retrying could append the content twice or send the reader already read.
+func (conf *client) AppendJobArtifact(job JobObject, path string, reader io.Reader) error {
+	req := conf.request()
+	req.SetContext(context.WithValue(req.Context(), requestMaxRetriesKey{}, 0))
+
+	resp, err := req.
+		SetHeaders(map[string]string{
+			HeaderConfirm: "true",
+		}).
+		SetBody(reader).
+		Put(filepath.Join(ArtifactFilesEndpoint, job.path(), path))
+	if err != nil {
+		return &errors.APIError{Err: err, Message: fmt.Sprintf("append artifact '%s' of job '%s'", path, job.path())}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	return nil
+}
+
+// downloadJobArtifact streams the artifact to the writer, false is returned when GoCD is yet to prepare the artifact.
+func (conf *client) downloadJobArtifact(job JobObject, path string, writer io.Writer) (bool, error) {
+	resp, err := conf.request().
+		SetDoNotParseResponse(true).
+		Get(filepath.Join(ArtifactFilesEndpoint, job.path(), path))
+	if err != nil {
+		return false, &errors.APIError{Err: err, Message: fmt.Sprintf("download artifact '%s' of job '%s'", path, job.path())}
+	}
+
+	defer resp.RawBody().Close()
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		if _, err = io.Copy(writer, resp.RawBody()); err != nil {
+			return false, &errors.GoCDError{Message: "writing artifact errored with", Err: err}
+		}
+
+		return true, nil
+	case http.StatusAccepted:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.RawBody())
+
+		return false, &errors.NonOkError{Code: resp.StatusCode(), Response: resp.SetBody(body)}
+	}
+}
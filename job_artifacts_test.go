@@ -0,0 +1,204 @@
+package gocd_test
+
+import (
+	"bytes"
+	_ "embed"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed internal/fixtures/job_artifacts.json
+var jobArtifactsJSON string
+
+var artifactJob = gocd.JobObject{
+	Pipeline:         "up42",
+	PipelineInstance: "1",
+	Stage:            "up42_stage",
+	StageCounter:     "1",
+	Name:             "up42_job",
+}
+
+func Test_client_GetJobArtifacts(t *testing.T) {
+	t.Run("should be able to fetch the artifacts uploaded by the job", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/files/up42/1/up42_stage/1/up42_job.json" {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(jobArtifactsJSON))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobArtifacts(artifactJob)
+		require.NoError(t, err)
+		assert.Len(t, actual, 2)
+		assert.Equal(t, "folder", actual[0].Type)
+		assert.Equal(t, []gocd.JobArtifact{{
+			Name: "console.log",
+			URL:  "https://ci.example.com/go/files/up42/1/up42_stage/1/up42_job/cruise-output/console.log",
+			Type: "file",
+		}}, actual[0].Files)
+		assert.Equal(t, "report.html", actual[1].Name)
+	})
+
+	t.Run("should error out while fetching artifacts as server returned malformed response", func(t *testing.T) {
+		server := mockServer([]byte(`[{"name"}]`), http.StatusOK, nil, true, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobArtifacts(artifactJob)
+		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_client_DownloadJobArtifact(t *testing.T) {
+	t.Run("should be able to download the artifact file to the writer", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/files/up42/1/up42_stage/1/up42_job/cruise-output/console.log" {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte("Job completed\n"))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		var buffer bytes.Buffer
+		err := client.DownloadJobArtifact(artifactJob, "cruise-output/console.log", &buffer)
+		require.NoError(t, err)
+		assert.Equal(t, "Job completed\n", buffer.String())
+	})
+
+	t.Run("should error out while downloading the artifact that does not exist", func(t *testing.T) {
+		server := mockServer([]byte("artifact not found"), http.StatusNotFound, nil, true, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		var buffer bytes.Buffer
+		err := client.DownloadJobArtifact(artifactJob, "report.html", &buffer)
+		require.EqualError(t, err, "got 404 from GoCD while making GET call for "+server.URL+
+			"/files/up42/1/up42_stage/1/up42_job/report.html\nwith BODY:artifact not found")
+		assert.Empty(t, buffer.String())
+	})
+
+	t.Run("should wait until GoCD prepares the zip of the artifact folder", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/files/up42/1/up42_stage/1/up42_job/cruise-output.zip" {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			if atomic.AddInt32(&calls, 1) == 1 {
+				writer.WriteHeader(http.StatusAccepted)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte("zip-content"))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		var buffer bytes.Buffer
+		err := client.DownloadJobArtifactDirectory(artifactJob, "cruise-output", &buffer)
+		require.NoError(t, err)
+		assert.Equal(t, "zip-content", buffer.String())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func Test_client_UploadJobArtifact(t *testing.T) {
+	t.Run("should be able to upload the artifact file", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodPost || req.Header.Get(gocd.HeaderConfirm) != "true" ||
+				req.URL.Path != "/files/up42/1/up42_stage/1/up42_job/reports/report.html" {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			file, header, err := req.FormFile("file")
+			if err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			content, _ := io.ReadAll(file)
+			if header.Filename != "report.html" || string(content) != "<html></html>" {
+				writer.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			writer.WriteHeader(http.StatusCreated)
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.UploadJobArtifact(artifactJob, "reports/report.html", strings.NewReader("<html></html>"))
+		require.NoError(t, err)
+	})
+
+	t.Run("should error out while uploading the artifact as server returned non 201 status code", func(t *testing.T) {
+		server := mockServer([]byte("artifact already exists"), http.StatusForbidden, nil, true, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.UploadJobArtifact(artifactJob, "report.html", strings.NewReader("<html></html>"))
+		require.EqualError(t, err, "got 403 from GoCD while making POST call for "+server.URL+
+			"/files/up42/1/up42_stage/1/up42_job/report.html\nwith BODY:artifact already exists")
+	})
+}
+
+func Test_client_AppendJobArtifact(t *testing.T) {
+	t.Run("should be able to append to the artifact file", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			content, _ := io.ReadAll(req.Body)
+			if req.Method != http.MethodPut || req.Header.Get(gocd.HeaderConfirm) != "true" ||
+				req.URL.Path != "/files/up42/1/up42_stage/1/up42_job/cruise-output/console.log" || string(content) != "more logs\n" {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.AppendJobArtifact(artifactJob, "cruise-output/console.log", strings.NewReader("more logs\n"))
+		require.NoError(t, err)
+	})
+
+	t.Run("should not retry appending to the artifact file when the call fails", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodPut {
+				calls.Add(1)
+			}
+
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		policy := gocd.DefaultRetryPolicy()
+		policy.InitialInterval, policy.RetryOnStatus = time.Millisecond, gocd.TransientStatusCodes
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		err = client.AppendJobArtifact(artifactJob, "cruise-output/console.log", strings.NewReader("more logs\n"))
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
@@ -3,10 +3,12 @@ package gocd
 import (
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
+	"io"
 	"net/http"
 	"path/filepath"
+	"time"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
@@ -14,12 +16,7 @@ import (
 func (conf *client) GetScheduledJobs() (ScheduledJobs, error) {
 	var scheduledJobs ScheduledJobs
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return scheduledJobs, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		Get(APIJobFeedEndpoint)
 	if err != nil {
 		return scheduledJobs, &errors.APIError{Err: err, Message: "get scheduled jobs"}
@@ -38,12 +35,7 @@ func (conf *client) GetScheduledJobs() (ScheduledJobs, error) {
 
 // RunFailedJobs runs all failed jobs from a selected pipeline.
 func (conf *client) RunFailedJobs(stage Stage) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionThree,
 			HeaderConfirm: "true",
@@ -68,12 +60,7 @@ func (conf *client) RunFailedJobs(stage Stage) (string, error) {
 
 // RunJobs runs all selected jobs from a selected pipeline.
 func (conf *client) RunJobs(stage Stage) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetBody(stage).
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionThree,
@@ -96,3 +83,142 @@ func (conf *client) RunJobs(stage Stage) (string, error) {
 
 	return message["message"], nil
 }
+
+// GetJobInstance fetches the information of a specific run of the job.
+func (conf *client) GetJobInstance(job JobObject) (JobInstance, error) {
+	var jobInstance JobInstance
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionOne,
+		}).
+		Get(filepath.Join(JobsAPIEndpoint, job.Pipeline, job.PipelineInstance, job.Stage, job.StageCounter, job.Name))
+	if err != nil {
+		return jobInstance, &errors.APIError{Err: err, Message: fmt.Sprintf("get job instance '%s'", job.path())}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return jobInstance, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &jobInstance); err != nil {
+		return jobInstance, &errors.MarshalError{Err: err}
+	}
+
+	return jobInstance, nil
+}
+
+// GetJobHistory fetches a page of run history of the selected job, latest runs are returned first.
+// Pass the Next cursor of the returned JobHistory as after to fetch the subsequent page, empty after fetches the first page.
+func (conf *client) GetJobHistory(pipeline, stage, job, pageSize, after string) (JobHistory, error) {
+	type jobHistory struct {
+		Links pageLinks     `json:"_links,omitempty"`
+		Jobs  []JobInstance `json:"jobs,omitempty"`
+	}
+
+	queryParams := map[string]string{"page_size": pageSize}
+	if len(after) != 0 {
+		queryParams["after"] = after
+	}
+
+	var history jobHistory
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionOne,
+		}).
+		SetQueryParams(queryParams).
+		Get(filepath.Join(JobsAPIEndpoint, pipeline, stage, job, "history"))
+	if err != nil {
+		return JobHistory{}, &errors.APIError{Err: err, Message: fmt.Sprintf("get job history for '%s/%s/%s'", pipeline, stage, job)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return JobHistory{}, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &history); err != nil {
+		return JobHistory{}, &errors.MarshalError{Err: err}
+	}
+
+	return JobHistory{
+		Jobs:     history.Jobs,
+		Next:     history.Links.Next.cursor("after"),
+		Previous: history.Links.Previous.cursor("before"),
+	}, nil
+}
+
+// GetJobConsoleLog fetches the console log of the job starting from the passed byte offset, pass 0 to fetch the entire log.
+// The Offset of the returned ConsoleLog could be passed on subsequent calls to fetch only the newer part of the log.
+func (conf *client) GetJobConsoleLog(job JobObject, offset int64) (ConsoleLog, error) {
+	request := conf.request()
+
+	if offset > 0 {
+		request.SetHeader("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := request.
+		Get(filepath.Join(ArtifactFilesEndpoint, job.path(), consoleLogPath))
+	if err != nil {
+		return ConsoleLog{}, &errors.APIError{Err: err, Message: fmt.Sprintf("get console log of job '%s'", job.path())}
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusPartialContent:
+		return ConsoleLog{Content: resp.Body(), Offset: offset + int64(len(resp.Body()))}, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// nothing new was written to the console log since the offset passed.
+		return ConsoleLog{Content: []byte{}, Offset: offset}, nil
+	case http.StatusOK:
+		// server does not honour the range requests, hence the content before offset is discarded here.
+		body := resp.Body()
+		if offset >= int64(len(body)) {
+			return ConsoleLog{Content: []byte{}, Offset: offset}, nil
+		}
+
+		return ConsoleLog{Content: body[offset:], Offset: int64(len(body))}, nil
+	default:
+		return ConsoleLog{}, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+}
+
+// StreamJobConsoleLog writes the console log of the job to the writer as it is written by GoCD,
+// polling every interval until the job completes. Cancel the context bound to the client to stop streaming early.
+func (conf *client) StreamJobConsoleLog(job JobObject, writer io.Writer, interval time.Duration) error {
+	var offset int64
+
+	for {
+		jobInstance, err := conf.GetJobInstance(job)
+		if err != nil {
+			return err
+		}
+
+		consoleLog, err := conf.GetJobConsoleLog(job, offset)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		if len(consoleLog.Content) != 0 {
+			if _, err = writer.Write(consoleLog.Content); err != nil {
+				return &errors.GoCDError{Message: "writing console log errored with", Err: err}
+			}
+
+			offset = consoleLog.Offset
+		}
+
+		if jobInstance.State == jobStateCompleted {
+			return nil
+		}
+
+		select {
+		case <-conf.getContext().Done():
+			return &errors.APIError{Err: conf.getContext().Err(), Message: fmt.Sprintf("stream console log of job '%s'", job.path())}
+		case <-time.After(interval):
+		}
+	}
+}
+
+// path returns the path that identifies the run of the job in GoCD.
+func (job JobObject) path() string {
+	return filepath.Join(job.Pipeline, job.PipelineInstance, job.Stage, job.StageCounter, job.Name)
+}
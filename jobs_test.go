@@ -1,17 +1,28 @@
 package gocd_test
 
 import (
+	"bytes"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/nikhilsbhat/gocd-sdk-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-//go:embed internal/fixtures/scheduled_jobs.xml
-var scheduledJobJSON string
+var (
+	//go:embed internal/fixtures/scheduled_jobs.xml
+	scheduledJobJSON string
+	//go:embed internal/fixtures/job_instance.json
+	jobInstanceJSON string
+	//go:embed internal/fixtures/job_history.json
+	jobHistoryJSON string
+)
 
 func Test_client_ScheduledJobs(t *testing.T) {
 	t.Run("should error out while fetching scheduled jobs from server", func(t *testing.T) {
@@ -170,3 +181,155 @@ func Test_client_RunFailedJobs(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func Test_client_GetJobInstance(t *testing.T) {
+	correctJobHeader := map[string]string{"Accept": gocd.HeaderVersionOne}
+	job := gocd.JobObject{Pipeline: "up42", PipelineInstance: "2", Stage: "up42_stage", StageCounter: "1", Name: "up42_job"}
+
+	t.Run("should be able to fetch the job instance", func(t *testing.T) {
+		server := mockServer([]byte(jobInstanceJSON), http.StatusOK, correctJobHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobInstance(job)
+		require.NoError(t, err)
+		assert.Equal(t, "up42_job", actual.Name)
+		assert.Equal(t, "Completed", actual.State)
+		assert.Equal(t, "Passed", actual.Result)
+		assert.Equal(t, "1", actual.StageCounter)
+		assert.Equal(t, int64(2), actual.PipelineCounter)
+		assert.Len(t, actual.JobStateTransitions, 4)
+	})
+
+	t.Run("should error out while fetching the job instance as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("jobInstanceJSON"), http.StatusNotFound, correctJobHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobInstance(job)
+		require.EqualError(t, err, "got 404 from GoCD while making GET call for "+server.URL+
+			"/api/jobs/up42/2/up42_stage/1/up42_job\nwith BODY:jobInstanceJSON")
+		assert.Equal(t, gocd.JobInstance{}, actual)
+	})
+}
+
+func Test_client_GetJobHistory(t *testing.T) {
+	correctJobHeader := map[string]string{"Accept": gocd.HeaderVersionOne}
+
+	t.Run("should be able to fetch a page of job history along with the cursors", func(t *testing.T) {
+		server := mockServer([]byte(jobHistoryJSON), http.StatusOK, correctJobHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobHistory("up42", "up42_stage", "up42_job", "2", "")
+		require.NoError(t, err)
+		assert.Len(t, actual.Jobs, 2)
+		assert.Equal(t, "Failed", actual.Jobs[0].Result)
+		assert.Equal(t, "2", actual.Next)
+		assert.Equal(t, "4", actual.Previous)
+	})
+
+	t.Run("should error out while fetching job history as server returned malformed response", func(t *testing.T) {
+		server := mockServer([]byte(`{"jobs"}`), http.StatusOK, correctJobHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobHistory("up42", "up42_stage", "up42_job", "2", "")
+		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
+		assert.Equal(t, gocd.JobHistory{}, actual)
+	})
+}
+
+func Test_client_GetJobConsoleLog(t *testing.T) {
+	job := gocd.JobObject{Pipeline: "up42", PipelineInstance: "2", Stage: "up42_stage", StageCounter: "1", Name: "up42_job"}
+	consoleLog := "Start to prepare up42/2/up42_stage/1/up42_job\nStart to build\nJob completed\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/files/up42/2/up42_stage/1/up42_job/cruise-output/console.log" {
+			writer.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		http.ServeContent(writer, req, "console.log", time.Time{}, strings.NewReader(consoleLog))
+	}))
+	defer server.Close()
+
+	client := gocd.NewClient(server.URL, auth, "info", nil)
+
+	t.Run("should be able to fetch the entire console log", func(t *testing.T) {
+		actual, err := client.GetJobConsoleLog(job, 0)
+		require.NoError(t, err)
+		assert.Equal(t, consoleLog, string(actual.Content))
+		assert.Equal(t, int64(len(consoleLog)), actual.Offset)
+	})
+
+	t.Run("should be able to fetch the console log from the offset", func(t *testing.T) {
+		actual, err := client.GetJobConsoleLog(job, 46)
+		require.NoError(t, err)
+		assert.Equal(t, "Start to build\nJob completed\n", string(actual.Content))
+		assert.Equal(t, int64(len(consoleLog)), actual.Offset)
+	})
+
+	t.Run("should return empty content when nothing new is written after the offset", func(t *testing.T) {
+		actual, err := client.GetJobConsoleLog(job, int64(len(consoleLog)))
+		require.NoError(t, err)
+		assert.Empty(t, actual.Content)
+		assert.Equal(t, int64(len(consoleLog)), actual.Offset)
+	})
+
+	t.Run("should discard the content before offset when server does not support range requests", func(t *testing.T) {
+		server := mockServer([]byte(consoleLog), http.StatusOK, nil, true, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetJobConsoleLog(job, 46)
+		require.NoError(t, err)
+		assert.Equal(t, "Start to build\nJob completed\n", string(actual.Content))
+	})
+
+	t.Run("should error out while fetching console log of the job that does not exist", func(t *testing.T) {
+		actual, err := client.GetJobConsoleLog(gocd.JobObject{Pipeline: "up43"}, 0)
+		require.EqualError(t, err, "got 404 from GoCD while making GET call for "+server.URL+
+			"/files/up43/cruise-output/console.log\nwith BODY:")
+		assert.Equal(t, gocd.ConsoleLog{}, actual)
+	})
+}
+
+func Test_client_StreamJobConsoleLog(t *testing.T) {
+	job := gocd.JobObject{Pipeline: "up42", PipelineInstance: "2", Stage: "up42_stage", StageCounter: "1", Name: "up42_job"}
+	chunks := []string{"Start to prepare up42/2/up42_stage/1/up42_job\n", "Start to build\n", "Job completed\n"}
+
+	var (
+		mutex sync.Mutex
+		calls int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if strings.HasPrefix(req.URL.Path, "/api/jobs") {
+			calls++
+
+			state := "Building"
+			if calls >= len(chunks) {
+				state = "Completed"
+			}
+
+			_, _ = writer.Write([]byte(`{"name": "up42_job", "state": "` + state + `"}`))
+
+			return
+		}
+
+		consoleLog := strings.Join(chunks[:calls], "")
+		http.ServeContent(writer, req, "console.log", time.Time{}, strings.NewReader(consoleLog))
+	}))
+	defer server.Close()
+
+	client := gocd.NewClient(server.URL, auth, "info", nil)
+
+	t.Run("should stream the console log until the job completes", func(t *testing.T) {
+		var output bytes.Buffer
+
+		err := client.StreamJobConsoleLog(job, &output, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join(chunks, ""), output.String())
+		assert.Equal(t, len(chunks), calls)
+	})
+}
@@ -0,0 +1,281 @@
+package gocd
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// EndpointClass groups the endpoints of GoCD by the load the calls made to them put on the server,
+// so that they could be limited differently using WithEndpointLimit.
+type EndpointClass string
+
+const (
+	// EndpointClassRead is for the GET calls not part of EndpointClassFeed, e.g. GetAgent.
+	EndpointClassRead EndpointClass = "read"
+	// EndpointClassFeed is for the GET calls to the feeds, histories, value stream maps and cctray,
+	// which are expensive for GoCD to serve, e.g. GetPipelineRunHistory.
+	EndpointClassFeed EndpointClass = "feed"
+	// EndpointClassWrite is for the calls other than GET not part of EndpointClassAdminWrite, e.g. SchedulePipeline.
+	EndpointClassWrite EndpointClass = "write"
+	// EndpointClassAdminWrite is for the calls other than GET to the admin endpoints and the agents, which change the
+	// configuration of GoCD, e.g. CreatePipeline, UpdateAgent, DeleteAgent or AgentKillTask.
+	EndpointClassAdminWrite EndpointClass = "admin_write"
+)
+
+// Limit caps the calls made to GoCD, the zero value of each of the fields leaves the respective cap off.
+type Limit struct {
+	// RequestsPerSecond is the rate at which the tokens are added to the bucket, every attempt made takes one.
+	RequestsPerSecond float64
+	// Burst is the size of the bucket, which is the number of attempts that could be made at once, defaults to 1.
+	Burst int
+	// MaxInFlight is the number of attempts that could wait on GoCD at once, an attempt is done once its response
+	// is read. Calls are made one after the other with 1, e.g. to run GetPipelineRunHistory off the peak load.
+	MaxInFlight int
+}
+
+// limiter enforces a Limit, calls beyond it wait for their turn until their context is done.
+type limiter struct {
+	bucket   *tokenBucket
+	inFlight chan struct{}
+}
+
+// limits holds the limiters of the client, the one of the class of the endpoint called is used when set.
+type limits struct {
+	client  *limiter
+	classes map[EndpointClass]*limiter
+}
+
+// WithLimit caps the calls made by the client to GoCD, for the endpoint classes not capped by WithEndpointLimit.
+// The cap is applied to every attempt made, retries included.
+func WithLimit(limit Limit) Option {
+	return func(opts *options) error {
+		if err := limit.validate(); err != nil {
+			return err
+		}
+
+		opts.limit = &limit
+
+		return nil
+	}
+}
+
+// WithEndpointLimit caps the calls made by the client to the endpoints of the class, overriding the cap set by
+// WithLimit for them, e.g. to let the admin writes through one at a time while keeping the reads faster.
+func WithEndpointLimit(class EndpointClass, limit Limit) Option {
+	return func(opts *options) error {
+		if err := limit.validate(); err != nil {
+			return err
+		}
+
+		if opts.endpointLimits == nil {
+			opts.endpointLimits = make(map[EndpointClass]Limit)
+		}
+
+		opts.endpointLimits[class] = limit
+
+		return nil
+	}
+}
+
+func (limit Limit) validate() error {
+	if limit.RequestsPerSecond < 0 || limit.Burst < 0 || limit.MaxInFlight < 0 {
+		return &errors.GoCDSDKError{Message: "limits of the calls made to GoCD cannot be negative"}
+	}
+
+	return nil
+}
+
+// setLimits wraps the transport of the resty client to cap the calls made, nothing is done when no limit is set.
+func setLimits(httpClient *resty.Client, limit *Limit, endpointLimits map[EndpointClass]Limit) {
+	if limit == nil && len(endpointLimits) == 0 {
+		return
+	}
+
+	clientLimits := &limits{classes: make(map[EndpointClass]*limiter, len(endpointLimits))}
+
+	if limit != nil {
+		clientLimits.client = newLimiter(*limit)
+	}
+
+	for class, endpointLimit := range endpointLimits {
+		clientLimits.classes[class] = newLimiter(endpointLimit)
+	}
+
+	next := httpClient.GetClient().Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	httpClient.SetTransport(&limitedTransport{next: next, limits: clientLimits})
+}
+
+func newLimiter(limit Limit) *limiter {
+	newLimiter := &limiter{}
+
+	if limit.RequestsPerSecond > 0 {
+		newLimiter.bucket = newTokenBucket(limit.RequestsPerSecond, max(limit.Burst, 1))
+	}
+
+	if limit.MaxInFlight > 0 {
+		newLimiter.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return newLimiter
+}
+
+// acquire waits for the turn of the attempt, the release returned is to be called once the attempt is done.
+func (limiter *limiter) acquire(ctx context.Context) (func(), error) {
+	if limiter.bucket != nil {
+		if err := limiter.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if limiter.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case limiter.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+
+	return func() { once.Do(func() { <-limiter.inFlight }) }, nil
+}
+
+// limiterFor returns the limiter of the class of the endpoint called, falling back to the one of the client.
+func (limits *limits) limiterFor(req *http.Request) *limiter {
+	if classLimiter, ok := limits.classes[endpointClassOf(req)]; ok {
+		return classLimiter
+	}
+
+	return limits.client
+}
+
+// endpointClassOf returns the EndpointClass of the endpoint called by the request.
+func endpointClassOf(req *http.Request) EndpointClass {
+	path := req.URL.Path
+
+	switch {
+	case req.Method != http.MethodGet && req.Method != http.MethodHead &&
+		(strings.Contains(path, "/api/admin/") || strings.Contains(path, AgentsEndpoint)):
+		return EndpointClassAdminWrite
+	case req.Method != http.MethodGet && req.Method != http.MethodHead:
+		return EndpointClassWrite
+	case strings.Contains(path, "/api/feed/"), strings.HasSuffix(path, "history"),
+		strings.Contains(path, "value_stream_map"), strings.HasSuffix(path, "cctray.xml"):
+		return EndpointClassFeed
+	default:
+		return EndpointClassRead
+	}
+}
+
+// limitedTransport makes each round trip of the transport wrapped wait for its turn as per the limits.
+type limitedTransport struct {
+	next   http.RoundTripper
+	limits *limits
+}
+
+func (transport *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := transport.limits.limiterFor(req)
+	if limiter == nil {
+		return transport.next.RoundTrip(req)
+	}
+
+	release, err := limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := transport.next.RoundTrip(req)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releasingBody releases the turn of the attempt once the body of its response is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (body *releasingBody) Close() error {
+	defer body.release()
+
+	return body.ReadCloser.Close()
+}
+
+// tokenBucket is a token bucket filled at the rate set, up to its size.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	size   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, size int) *tokenBucket {
+	return &tokenBucket{rate: rate, size: float64(size), tokens: float64(size), last: time.Now()}
+}
+
+// wait takes a token from the bucket, waiting for it to be added when the bucket is empty.
+func (bucket *tokenBucket) wait(ctx context.Context) error {
+	delay := bucket.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		bucket.cancel()
+
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket, letting the tokens go negative, and returns the time until it is available.
+func (bucket *tokenBucket) reserve() time.Duration {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	now := time.Now()
+	bucket.tokens = math.Min(bucket.size, bucket.tokens+now.Sub(bucket.last).Seconds()*bucket.rate)
+	bucket.last = now
+	bucket.tokens--
+
+	if bucket.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// cancel returns the token reserved by an attempt which gave up waiting for it.
+func (bucket *tokenBucket) cancel() {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	bucket.tokens = math.Min(bucket.size, bucket.tokens+1)
+}
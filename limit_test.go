@@ -0,0 +1,152 @@
+package gocd_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowServer responds after the delay passed, recording the most calls it was serving at once.
+func slowServer(delay time.Duration, maxInFlight *atomic.Int32) *httptest.Server {
+	var inFlight atomic.Int32
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(delay)
+
+		_, _ = writer.Write([]byte(`{}`))
+	}))
+}
+
+func TestWithLimit(t *testing.T) {
+	t.Run("should not have more calls waiting on GoCD than the max in flight", func(t *testing.T) {
+		var maxInFlight atomic.Int32
+
+		server := slowServer(50*time.Millisecond, &maxInFlight)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithLimit(gocd.Limit{MaxInFlight: 2}))
+		require.NoError(t, err)
+
+		var waitGroup sync.WaitGroup
+
+		for range 6 {
+			waitGroup.Add(1)
+
+			go func() {
+				defer waitGroup.Done()
+
+				_, agentErr := client.GetAgent("agent-id")
+				assert.NoError(t, agentErr)
+			}()
+		}
+
+		waitGroup.Wait()
+
+		assert.Equal(t, int32(2), maxInFlight.Load())
+	})
+
+	t.Run("should make the calls at the rate set", func(t *testing.T) {
+		var maxInFlight atomic.Int32
+
+		server := slowServer(0, &maxInFlight)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithLimit(gocd.Limit{RequestsPerSecond: 20}))
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		for range 5 {
+			_, err = client.GetAgent("agent-id")
+			require.NoError(t, err)
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	})
+
+	t.Run("should limit the endpoint class by its own limit over the one of the client", func(t *testing.T) {
+		var maxInFlight atomic.Int32
+
+		server := slowServer(0, &maxInFlight)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL,
+			gocd.WithLimit(gocd.Limit{RequestsPerSecond: 1}),
+			gocd.WithEndpointLimit(gocd.EndpointClassRead, gocd.Limit{RequestsPerSecond: 1000, Burst: 5}),
+		)
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		for range 5 {
+			_, err = client.GetAgent("agent-id")
+			require.NoError(t, err)
+		}
+
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("should stop waiting for the turn once the context is done", func(t *testing.T) {
+		var maxInFlight atomic.Int32
+
+		server := slowServer(0, &maxInFlight)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetry(0, 0), gocd.WithLimit(gocd.Limit{RequestsPerSecond: 0.1}))
+		require.NoError(t, err)
+
+		_, err = client.GetAgent("agent-id")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = client.WithContext(ctx).GetAgent("agent-id")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("should limit the writes to the agents as the admin writes", func(t *testing.T) {
+		var maxInFlight atomic.Int32
+
+		server := slowServer(0, &maxInFlight)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetry(0, 0),
+			gocd.WithEndpointLimit(gocd.EndpointClassAdminWrite, gocd.Limit{RequestsPerSecond: 0.1}))
+		require.NoError(t, err)
+
+		require.NoError(t, client.UpdateAgent(gocd.Agent{ID: "agent-id"}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err = client.WithContext(ctx).UpdateAgent(gocd.Agent{ID: "agent-id"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		_, err = client.GetAgent("agent-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("should error out when the limit is negative", func(t *testing.T) {
+		_, err := gocd.New("http://localhost:8156/go", gocd.WithEndpointLimit(gocd.EndpointClassFeed, gocd.Limit{MaxInFlight: -1}))
+		require.EqualError(t, err, "limits of the calls made to GoCD cannot be negative")
+	})
+}
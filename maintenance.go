@@ -5,18 +5,12 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // EnableMaintenanceMode enables maintenance mode.
 func (conf *client) EnableMaintenanceMode() error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionOne,
 			HeaderConfirm: "true",
@@ -35,14 +29,9 @@ func (conf *client) EnableMaintenanceMode() error {
 
 // GetMaintenanceModeInfo fetches the latest information of server maintenance mode information.
 func (conf *client) GetMaintenanceModeInfo() (Maintenance, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Maintenance{}, err
-	}
-
 	var maintenanceInfo Maintenance
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -64,12 +53,7 @@ func (conf *client) GetMaintenanceModeInfo() (Maintenance, error) {
 
 // DisableMaintenanceMode disables the maintenance mode.
 func (conf *client) DisableMaintenanceMode() error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionOne,
 			HeaderConfirm: "true",
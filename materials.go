@@ -5,19 +5,13 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetMaterials() ([]Material, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var materials Materials
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionZero,
 		}).
@@ -38,14 +32,9 @@ func (conf *client) GetMaterials() ([]Material, error) {
 }
 
 func (conf *client) GetMaterialUsage(materialID string) ([]string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var materialUsage MaterialUsage
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionZero,
 		}).
@@ -66,12 +55,7 @@ func (conf *client) GetMaterialUsage(materialID string) ([]string, error) {
 }
 
 func (conf *client) NotifyMaterial(material Material) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -98,12 +82,7 @@ func (conf *client) NotifyMaterial(material Material) (string, error) {
 }
 
 func (conf *client) MaterialTriggerUpdate(materialID string) (map[string]string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionZero,
 			HeaderConfirm: "true",
@@ -0,0 +1,100 @@
+package gocd
+
+import (
+	"fmt"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// ModifyOption customises how the Modify methods retry the updates conflicting with the changes made in between.
+type ModifyOption func(opts *modifyOptions)
+
+type modifyOptions struct {
+	attempts int
+}
+
+// WithModifyAttempts sets the times the object is fetched, mutated and updated before giving up on the ETag conflicts,
+// defaults to 3. Values less than 1 are taken as 1.
+func WithModifyAttempts(attempts int) ModifyOption {
+	return func(opts *modifyOptions) {
+		opts.attempts = max(attempts, 1)
+	}
+}
+
+// modify fetches the latest object, applies the mutation and updates it with the ETag fetched,
+// fetching and mutating again when GoCD responds with 412 as the object was modified in between.
+// The error from mutate is returned as is, without updating the object.
+// When every attempt conflicts, *errors.ModifyConflictError wrapping the error of the last attempt is returned.
+func modify[T any](conf *client, name string, get func() (T, error), mutate func(*T) error, update func(T) (T, error),
+	opts []ModifyOption,
+) (T, error) {
+	options := modifyOptions{attempts: defaultModifyAttempts}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var lastErr error
+
+	for attempt := 1; attempt <= options.attempts; attempt++ {
+		object, err := get()
+		if err != nil {
+			return object, err
+		}
+
+		if err = mutate(&object); err != nil {
+			return object, err
+		}
+
+		updated, err := update(object)
+		if err == nil || !errors.IsPreconditionFailed(err) {
+			return updated, err
+		}
+
+		conf.logger.Debugf("%s was modified while updating it, retrying with the latest one (attempt %d of %d)",
+			name, attempt, options.attempts)
+
+		lastErr = err
+	}
+
+	var object T
+
+	return object, &errors.ModifyConflictError{Object: name, Attempts: options.attempts, Err: lastErr}
+}
+
+// ModifyPipelineConfig fetches the latest config of the pipeline, applies the mutation and updates it using the ETag fetched.
+// It retries when the pipeline config was modified by someone else in between, see WithModifyAttempts.
+func (conf *client) ModifyPipelineConfig(name string, mutate func(config *PipelineConfig) error, opts ...ModifyOption) (PipelineConfig, error) {
+	return modify(conf, fmt.Sprintf("pipeline config '%s'", name), func() (PipelineConfig, error) {
+		return conf.GetPipelineConfig(name)
+	}, mutate, conf.UpdatePipelineConfig, opts)
+}
+
+// ModifyPipelineGroup fetches the latest pipeline group, applies the mutation and updates it using the ETag fetched.
+// It retries when the pipeline group was modified by someone else in between, see WithModifyAttempts.
+func (conf *client) ModifyPipelineGroup(name string, mutate func(group *PipelineGroup) error, opts ...ModifyOption) (PipelineGroup, error) {
+	return modify(conf, fmt.Sprintf("pipeline group '%s'", name), func() (PipelineGroup, error) {
+		return conf.GetPipelineGroup(name)
+	}, mutate, conf.UpdatePipelineGroup, opts)
+}
+
+// ModifyRole fetches the latest role, applies the mutation and updates it using the ETag fetched.
+// It retries when the role was modified by someone else in between, see WithModifyAttempts.
+func (conf *client) ModifyRole(name string, mutate func(role *Role) error, opts ...ModifyOption) (Role, error) {
+	return modify(conf, fmt.Sprintf("role '%s'", name), func() (Role, error) {
+		return conf.GetRole(name)
+	}, mutate, conf.UpdateRole, opts)
+}
+
+// ModifyEnvironment fetches the latest environment, applies the mutation and updates it using the ETag fetched.
+// It retries when the environment was modified by someone else in between, see WithModifyAttempts.
+func (conf *client) ModifyEnvironment(name string, mutate func(environment *Environment) error, opts ...ModifyOption) (Environment, error) {
+	return modify(conf, fmt.Sprintf("environment '%s'", name), func() (Environment, error) {
+		return conf.GetEnvironment(name)
+	}, mutate, conf.UpdateEnvironment, opts)
+}
+
+// ModifySystemAdmins fetches the latest system admins, applies the mutation and updates them using the ETag fetched.
+// It retries when the system admins were modified by someone else in between, see WithModifyAttempts.
+func (conf *client) ModifySystemAdmins(mutate func(admins *SystemAdmins) error, opts ...ModifyOption) (SystemAdmins, error) {
+	return modify(conf, "system admins", conf.GetSystemAdmins, mutate, conf.UpdateSystemAdmins, opts)
+}
@@ -0,0 +1,137 @@
+package gocd_test
+
+import (
+	"encoding/json"
+	goErrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errAliceNotAllowed = goErrors.New("alice is not allowed")
+
+// conflictingServer serves the admins with the ETag of the current version and fails the updates
+// with 412 for the first conflicts number of times, as if someone else modified them in between.
+func conflictingServer(conflicts int) (*httptest.Server, *[]string) {
+	version := 1
+	calls := make([]string, 0)
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		calls = append(calls, req.Method+" "+req.Header.Get("If-Match"))
+
+		if req.Method == http.MethodPut {
+			if req.Header.Get("If-Match") != fmt.Sprintf("etag-%d", version) || conflicts > 0 {
+				conflicts--
+				version++
+				writer.WriteHeader(http.StatusPreconditionFailed)
+
+				return
+			}
+
+			version++
+			writer.Header().Set("ETag", fmt.Sprintf("etag-%d", version))
+
+			var admins gocd.SystemAdmins
+			_ = json.NewDecoder(req.Body).Decode(&admins)
+			_ = json.NewEncoder(writer).Encode(admins)
+
+			return
+		}
+
+		writer.Header().Set("ETag", fmt.Sprintf("etag-%d", version))
+		_, _ = writer.Write([]byte(`{"roles": ["manager"], "users": ["john"]}`))
+	})), &calls
+}
+
+func Test_client_ModifySystemAdmins(t *testing.T) {
+	addAlice := func(admins *gocd.SystemAdmins) error {
+		admins.Users = append(admins.Users, "alice")
+
+		return nil
+	}
+
+	t.Run("should fetch, mutate and update the system admins with the ETag fetched", func(t *testing.T) {
+		server, calls := conflictingServer(0)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		admins, err := client.ModifySystemAdmins(addAlice)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"john", "alice"}, admins.Users)
+		assert.Equal(t, "etag-2", admins.ETAG)
+		assert.Equal(t, []string{"GET ", "PUT etag-1"}, *calls)
+	})
+
+	t.Run("should refetch and mutate the latest system admins when they were modified in between", func(t *testing.T) {
+		server, calls := conflictingServer(2)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		admins, err := client.ModifySystemAdmins(addAlice)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"john", "alice"}, admins.Users)
+		assert.Equal(t, []string{"GET ", "PUT etag-1", "GET ", "PUT etag-2", "GET ", "PUT etag-3"}, *calls)
+	})
+
+	t.Run("should give up when the system admins were modified on every attempt", func(t *testing.T) {
+		server, calls := conflictingServer(3)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		_, err := client.ModifySystemAdmins(addAlice)
+		require.Error(t, err)
+		assert.True(t, errors.IsPreconditionFailed(err))
+		assert.ErrorIs(t, err, errors.ErrPreconditionFailed)
+		assert.Contains(t, err.Error(), "updating system admins failed as its ETag conflicted with GoCD on every of 3 attempts")
+
+		var conflictErr *errors.ModifyConflictError
+
+		require.True(t, goErrors.As(err, &conflictErr))
+		assert.Equal(t, 3, conflictErr.Attempts)
+		assert.Len(t, *calls, 6)
+	})
+
+	t.Run("should attempt the number of times set when the system admins were modified in between", func(t *testing.T) {
+		server, calls := conflictingServer(1)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		_, err := client.ModifySystemAdmins(addAlice, gocd.WithModifyAttempts(1))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errors.ErrPreconditionFailed)
+		assert.Contains(t, err.Error(), "on every of 1 attempts")
+		assert.Equal(t, []string{"GET ", "PUT etag-1"}, *calls)
+
+		server, calls = conflictingServer(4)
+		client = gocd.NewClient(server.URL, auth, "info", nil)
+
+		admins, err := client.ModifySystemAdmins(addAlice, gocd.WithModifyAttempts(5))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"john", "alice"}, admins.Users)
+		assert.Len(t, *calls, 10)
+	})
+
+	t.Run("should not update the system admins when the mutation errored", func(t *testing.T) {
+		server, calls := conflictingServer(0)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		_, err := client.ModifySystemAdmins(func(_ *gocd.SystemAdmins) error {
+			return errAliceNotAllowed
+		})
+		require.ErrorIs(t, err, errAliceNotAllowed)
+		assert.Equal(t, []string{"GET "}, *calls)
+	})
+}
+
+func Test_client_ModifyRole(t *testing.T) {
+	t.Run("should not retry when the role could not be fetched", func(t *testing.T) {
+		server := mockServer(nil, http.StatusNotFound, map[string]string{"Accept": gocd.HeaderVersionThree}, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		_, err := client.ModifyRole("blackbird", func(_ *gocd.Role) error { return nil })
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
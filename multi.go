@@ -0,0 +1,137 @@
+package gocd
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// MultiClient makes the calls to several GoCD servers at once, each of them known by a name, e.g. the region it serves.
+// The calls are made to the servers concurrently, with the results and the errors keyed by the name of the server.
+// When the call fails on some of the servers, the results of the rest are returned along with *errors.ServersError.
+type MultiClient struct {
+	clients map[string]GoCd
+}
+
+// NewMultiClient returns the MultiClient making the calls to the GoCD servers through the clients passed, keyed by their names.
+func NewMultiClient(clients map[string]GoCd) *MultiClient {
+	return &MultiClient{clients: maps.Clone(clients)}
+}
+
+// Servers returns the names of the GoCD servers the calls are made to, sorted.
+func (multi *MultiClient) Servers() []string {
+	return slices.Sorted(maps.Keys(multi.clients))
+}
+
+// Select returns the MultiClient making the calls only to the GoCD servers passed, e.g. to apply a write to a few of them.
+func (multi *MultiClient) Select(servers ...string) (*MultiClient, error) {
+	clients := make(map[string]GoCd, len(servers))
+
+	for _, server := range servers {
+		client, ok := multi.clients[server]
+		if !ok {
+			return nil, &errors.GoCDSDKError{Message: "GoCD server '" + server + "' is not known to the multi client"}
+		}
+
+		clients[server] = client
+	}
+
+	return &MultiClient{clients: clients}, nil
+}
+
+// WithContext returns a copy of the MultiClient with the clients of all the GoCD servers bound to the passed context.
+func (multi *MultiClient) WithContext(ctx context.Context) *MultiClient {
+	clients := make(map[string]GoCd, len(multi.clients))
+	for server, client := range multi.clients {
+		clients[server] = client.WithContext(ctx)
+	}
+
+	return &MultiClient{clients: clients}
+}
+
+// FanOut makes the call to all the GoCD servers of the MultiClient concurrently and returns the results keyed by
+// the name of the server, it is for the calls not offered by the MultiClient itself.
+func FanOut[T any](multi *MultiClient, call func(client GoCd) (T, error)) (map[string]T, error) {
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+	)
+
+	results := make(map[string]T, len(multi.clients))
+	serverErrors := make(map[string]error)
+
+	for server, client := range multi.clients {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			result, err := call(client)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				serverErrors[server] = err
+
+				return
+			}
+
+			results[server] = result
+		}()
+	}
+
+	waitGroup.Wait()
+
+	if len(serverErrors) != 0 {
+		return results, &errors.ServersError{Errors: serverErrors}
+	}
+
+	return results, nil
+}
+
+// Do makes the call, which is usually a write, to all the GoCD servers of the MultiClient concurrently.
+func (multi *MultiClient) Do(call func(client GoCd) error) error {
+	_, err := FanOut(multi, func(client GoCd) (struct{}, error) {
+		return struct{}{}, call(client)
+	})
+
+	return err
+}
+
+// GetAgents fetches the agents of all the GoCD servers.
+func (multi *MultiClient) GetAgents() (map[string][]Agent, error) {
+	return FanOut(multi, GoCd.GetAgents)
+}
+
+// GetPipelineGroups fetches the pipeline groups of all the GoCD servers.
+func (multi *MultiClient) GetPipelineGroups() (map[string][]PipelineGroup, error) {
+	return FanOut(multi, GoCd.GetPipelineGroups)
+}
+
+// GetServerHealthMessages fetches the server health messages of all the GoCD servers.
+func (multi *MultiClient) GetServerHealthMessages() (map[string][]ServerHealth, error) {
+	return FanOut(multi, GoCd.GetServerHealthMessages)
+}
+
+// GetVersionInfo fetches the version of all the GoCD servers.
+func (multi *MultiClient) GetVersionInfo() (map[string]VersionInfo, error) {
+	return FanOut(multi, GoCd.GetVersionInfo)
+}
+
+// CreateRole creates the role on all the GoCD servers.
+func (multi *MultiClient) CreateRole(config Role) (map[string]Role, error) {
+	return FanOut(multi, func(client GoCd) (Role, error) {
+		return client.CreateRole(config)
+	})
+}
+
+// UpdateSystemAdminsBulk applies the operations to the system admins of all the GoCD servers.
+func (multi *MultiClient) UpdateSystemAdminsBulk(data Operations) (map[string]SystemAdmins, error) {
+	return FanOut(multi, func(client GoCd) (SystemAdmins, error) {
+		return client.UpdateSystemAdminsBulk(data)
+	})
+}
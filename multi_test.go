@@ -0,0 +1,92 @@
+package gocd_test
+
+import (
+	"context"
+	goErrors "errors"
+	"sync"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regionClient is a fake GoCd serving a region, failing every call with err when set.
+type regionClient struct {
+	gocd.GoCd
+	region  string
+	err     error
+	mutex   *sync.Mutex
+	created *[]string
+}
+
+func (client regionClient) GetVersionInfo() (gocd.VersionInfo, error) {
+	if client.err != nil {
+		return gocd.VersionInfo{}, client.err
+	}
+
+	return gocd.VersionInfo{Version: "24.1.0", FullVersion: "24.1.0-" + client.region}, nil
+}
+
+func (client regionClient) CreateRole(config gocd.Role) (gocd.Role, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	*client.created = append(*client.created, client.region+"/"+config.Name)
+
+	return config, nil
+}
+
+func (client regionClient) WithContext(_ context.Context) gocd.GoCd {
+	return client
+}
+
+func newRegionClients(created *[]string) map[string]gocd.GoCd {
+	mutex := &sync.Mutex{}
+
+	return map[string]gocd.GoCd{
+		"us": regionClient{region: "us", mutex: mutex, created: created},
+		"eu": regionClient{region: "eu", mutex: mutex, created: created},
+		"ap": regionClient{region: "ap", mutex: mutex, created: created, err: goErrors.New("connection refused")},
+	}
+}
+
+func TestMultiClient(t *testing.T) {
+	created := make([]string, 0)
+	multi := gocd.NewMultiClient(newRegionClients(&created)).WithContext(context.Background())
+
+	t.Run("should list the names of the servers sorted", func(t *testing.T) {
+		assert.Equal(t, []string{"ap", "eu", "us"}, multi.Servers())
+	})
+
+	t.Run("should return the results keyed by the server along with the errors of the servers the call failed on", func(t *testing.T) {
+		versions, err := multi.GetVersionInfo()
+		require.EqualError(t, err, "call errored on 1 of the GoCD servers [ap: connection refused]")
+
+		var serversErr *errors.ServersError
+		require.ErrorAs(t, err, &serversErr)
+		assert.Contains(t, serversErr.Errors, "ap")
+
+		assert.Equal(t, map[string]gocd.VersionInfo{
+			"eu": {Version: "24.1.0", FullVersion: "24.1.0-eu"},
+			"us": {Version: "24.1.0", FullVersion: "24.1.0-us"},
+		}, versions)
+	})
+
+	t.Run("should apply the write only to the servers selected", func(t *testing.T) {
+		selected, err := multi.Select("eu", "us")
+		require.NoError(t, err)
+
+		roles, err := selected.CreateRole(gocd.Role{Name: "release-managers"})
+		require.NoError(t, err)
+
+		assert.Len(t, roles, 2)
+		assert.ElementsMatch(t, []string{"eu/release-managers", "us/release-managers"}, created)
+	})
+
+	t.Run("should error out when selecting the servers not known", func(t *testing.T) {
+		_, err := multi.Select("eu", "sa")
+		require.EqualError(t, err, "GoCD server 'sa' is not known to the multi client")
+	})
+}
@@ -0,0 +1,303 @@
+package gocd
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// APIVersion is a version of the API of an endpoint of GoCD and the versions of GoCD serving it.
+type APIVersion struct {
+	// Accept is the header requesting the version of the API, e.g. HeaderVersionSeven.
+	Accept string
+	// Since is the version of GoCD the version of the API was introduced in, e.g. 19.6.0, empty when served by all.
+	Since string
+	// Until is the version of GoCD the version of the API was removed in, empty when still served.
+	Until string
+}
+
+// APIVersions holds the versions of the API of the endpoints of GoCD, keyed by the endpoint, e.g. AgentsEndpoint.
+// The versions of an endpoint are to be listed from the oldest to the newest. The releases of GoCD serving them could
+// overlap, in which case the one set by the method wins when served, see WithVersionNegotiation.
+type APIVersions map[string][]APIVersion
+
+// versionNegotiator negotiates the versions of the API to be used with the GoCD server, detecting its version once.
+type versionNegotiator struct {
+	mutex         sync.Mutex
+	client        *client
+	versions      APIVersions
+	serverVersion string
+	// probe is closed once the call detecting the version of GoCD in flight is done, nil when no call is in flight.
+	probe chan struct{}
+	// failedAt is the time the last call detecting the version of GoCD failed at.
+	failedAt time.Time
+}
+
+// DefaultAPIVersions returns the versions of the API of the endpoints known to the SDK, those not listed here are called
+// with the Accept header set by their methods irrespective of the version of GoCD.
+// Each endpoint lists the version of the API it was introduced with and the release of GoCD introducing it, as per the
+// release notes of GoCD, so that the calls to the servers older than it error out. The versions of the API introduced
+// later are not listed, the Accept header set by the method is used for them; add them using WithVersionNegotiation.
+func DefaultAPIVersions() APIVersions {
+	return APIVersions{
+		// maintenance mode replaced drain mode in GoCD 19.1.0.
+		MaintenanceEndpoint: {{Accept: HeaderVersionOne, Since: "19.1.0"}},
+		// cluster profiles were introduced for the elastic agents in GoCD 19.3.0.
+		ClusterProfileEndpoint: {{Accept: HeaderVersionOne, Since: "19.3.0"}},
+		// secret configs were introduced with the secret management in GoCD 19.6.0.
+		SecretsConfigEndpoint: {{Accept: HeaderVersionOne, Since: "19.6.0"}},
+	}
+}
+
+// WithVersionNegotiation makes the client detect the version of the GoCD server on the first call made to the endpoints listed,
+// and pick the version of the API of each endpoint served by it. The versions passed are added to DefaultAPIVersions,
+// overriding the endpoints in it. The clients are created with DefaultAPIVersions negotiated, unless WithoutVersionNegotiation is set.
+//
+// The Accept header set by the method is used when the server serves it, or when it is not listed for the endpoint.
+// Otherwise, the newest of the versions served older than it is used, falling back to the oldest of the newer ones.
+// The calls to the endpoints not served by the server error out with *errors.UnsupportedOnServerVersion.
+func WithVersionNegotiation(versions APIVersions) Option {
+	return func(opts *options) error {
+		opts.apiVersions = DefaultAPIVersions()
+		maps.Copy(opts.apiVersions, versions)
+
+		return nil
+	}
+}
+
+// WithoutVersionNegotiation makes the client call GoCD with the Accept header set by the methods, without detecting its version.
+func WithoutVersionNegotiation() Option {
+	return func(opts *options) error {
+		opts.apiVersions = nil
+
+		return nil
+	}
+}
+
+// setVersionNegotiation sets the Accept header of the calls made as per the version of the GoCD server, nothing is done
+// when the version negotiation is not enabled.
+func setVersionNegotiation(httpClient *resty.Client, goCdClient *client, versions APIVersions) {
+	if versions == nil {
+		return
+	}
+
+	negotiator := &versionNegotiator{client: goCdClient, versions: versions}
+
+	httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		return negotiator.negotiate(req)
+	})
+}
+
+func (negotiator *versionNegotiator) negotiate(req *resty.Request) error {
+	if strings.Contains(req.URL, VersionEndpoint) {
+		return nil
+	}
+
+	endpoint, ok := negotiator.versions.endpointOf(negotiator.pathOf(req.URL))
+	if !ok {
+		return nil
+	}
+
+	serverVersion, ok := negotiator.detect(req.Context())
+	if !ok {
+		return nil
+	}
+
+	versions := negotiator.versions[endpoint]
+
+	accept, served := negotiateAccept(versions, req.Header.Get("Accept"), serverVersion)
+	if !served {
+		unsupportedErr := &errors.UnsupportedOnServerVersion{Method: callerMethod(), Endpoint: endpoint, ServerVersion: serverVersion}
+		if len(versions) != 0 && compareVersions(serverVersion, versions[0].Since) < 0 {
+			unsupportedErr.Since = versions[0].Since
+		}
+
+		return unsupportedErr
+	}
+
+	req.SetHeader("Accept", accept)
+
+	return nil
+}
+
+// detect returns the version of the GoCD server, it is fetched on the first call and again on the next calls when it failed,
+// once defaultVersionProbeBackoff passes. Only one call fetches it at a time, the concurrent calls wait for its outcome.
+// The version is fetched without the lock held and without retries, so that it does not hold up the calls waiting for it.
+func (negotiator *versionNegotiator) detect(ctx context.Context) (string, bool) {
+	for {
+		negotiator.mutex.Lock()
+
+		if serverVersion := negotiator.serverVersion; len(serverVersion) != 0 {
+			negotiator.mutex.Unlock()
+
+			return serverVersion, true
+		}
+
+		if probe := negotiator.probe; probe != nil {
+			negotiator.mutex.Unlock()
+
+			select {
+			case <-probe:
+				continue
+			case <-ctx.Done():
+				return "", false
+			}
+		}
+
+		if time.Since(negotiator.failedAt) < defaultVersionProbeBackoff*time.Second {
+			negotiator.mutex.Unlock()
+
+			return "", false
+		}
+
+		probe := make(chan struct{})
+		negotiator.probe = probe
+		negotiator.mutex.Unlock()
+
+		return negotiator.fetch(ctx, probe)
+	}
+}
+
+// fetch fetches the version of the GoCD server, closing the probe once done so that the calls waiting for it proceed.
+func (negotiator *versionNegotiator) fetch(ctx context.Context, probe chan struct{}) (string, bool) {
+	versionInfo, err := negotiator.client.WithContext(ctx).WithRequestOptions(WithMaxRetries(0)).GetVersionInfo()
+
+	negotiator.mutex.Lock()
+	defer negotiator.mutex.Unlock()
+
+	negotiator.probe = nil
+	close(probe)
+
+	switch {
+	case err == nil && len(versionInfo.Version) != 0:
+		negotiator.serverVersion = versionInfo.Version
+
+		return negotiator.serverVersion, true
+	case ctx.Err() == nil:
+		// the call is not backed off when it failed only because its caller gave up on it.
+		negotiator.failedAt = time.Now()
+	}
+
+	negotiator.client.logger.Debugf("detecting the version of GoCD server failed, calling it with the default API versions: %v", err)
+
+	return "", false
+}
+
+// pathOf returns the path of the call made to the URL, relative to the base URL of the GoCD server and without the query.
+func (negotiator *versionNegotiator) pathOf(url string) string {
+	path := strings.TrimPrefix(url, strings.TrimSuffix(negotiator.client.httpClient.BaseURL, "/"))
+	path, _, _ = strings.Cut(path, "?")
+
+	return path
+}
+
+// endpointOf returns the longest of the endpoints listed that the call made to the path is for, matching them part by part.
+// An endpoint matches the calls made to it and to the paths under it, e.g. AgentsEndpoint matches /api/agents and
+// /api/agents/{uuid} but not /api/agents_info, so the paths under it served by another API, like JobRunHistoryEndpoint,
+// are to be listed on their own. The parts of the endpoint set as %s match any part of the path.
+func (versions APIVersions) endpointOf(path string) (string, bool) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	var matched string
+
+	for endpoint := range versions {
+		if matchesEndpoint(pathParts, strings.Split(strings.Trim(endpoint, "/"), "/")) && len(endpoint) > len(matched) {
+			matched = endpoint
+		}
+	}
+
+	return matched, len(matched) != 0
+}
+
+// matchesEndpoint reports whether the parts of the path start with the ones of the endpoint.
+func matchesEndpoint(pathParts, endpointParts []string) bool {
+	if len(pathParts) < len(endpointParts) {
+		return false
+	}
+
+	for index, endpointPart := range endpointParts {
+		if endpointPart != "%s" && endpointPart != pathParts[index] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// negotiateAccept returns the Accept header to be used for calling the endpoint having the versions on the GoCD server,
+// and whether the endpoint is served by it at all.
+func negotiateAccept(versions []APIVersion, accept, serverVersion string) (string, bool) {
+	requested := -1
+	served := make([]int, 0, len(versions))
+
+	for index, version := range versions {
+		if version.Accept == accept {
+			requested = index
+		}
+
+		if version.servedBy(serverVersion) {
+			served = append(served, index)
+		}
+	}
+
+	switch {
+	case len(served) == 0:
+		return "", false
+	case requested == -1:
+		return accept, true
+	}
+
+	negotiated := served[0]
+
+	for _, index := range served {
+		if index <= requested {
+			negotiated = index
+		}
+	}
+
+	return versions[negotiated].Accept, true
+}
+
+// servedBy reports whether the GoCD server of the version passed serves the version of the API.
+func (version APIVersion) servedBy(serverVersion string) bool {
+	if len(version.Since) != 0 && compareVersions(serverVersion, version.Since) < 0 {
+		return false
+	}
+
+	return len(version.Until) == 0 || compareVersions(serverVersion, version.Until) < 0
+}
+
+// compareVersions compares the versions of GoCD like 23.1.0 part by part, returning -1, 0 or 1 as left is older,
+// same as or newer than right. Parts that are not numbers, like the build number, are ignored.
+func compareVersions(left, right string) int {
+	leftParts, rightParts := strings.Split(left, "."), strings.Split(right, ".")
+
+	for index := range max(len(leftParts), len(rightParts)) {
+		leftPart, rightPart := versionPart(leftParts, index), versionPart(rightParts, index)
+
+		switch {
+		case leftPart < rightPart:
+			return -1
+		case leftPart > rightPart:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func versionPart(parts []string, index int) int {
+	if index >= len(parts) {
+		return 0
+	}
+
+	part, _ := strconv.Atoi(strings.TrimSpace(parts[index]))
+
+	return part
+}
@@ -0,0 +1,292 @@
+package gocd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedServer serves the version passed, recording the Accept header of the last call made to the other endpoints.
+func versionedServer(version string, versionCalls *atomic.Int32, accept *atomic.Value) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, gocd.VersionEndpoint) {
+			versionCalls.Add(1)
+
+			if len(version) == 0 {
+				writer.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{"version": "` + version + `", "full_version": "` + version + `-1"}`))
+
+			return
+		}
+
+		accept.Store(req.Header.Get("Accept"))
+		_, _ = writer.Write([]byte(`{}`))
+	}))
+}
+
+func TestWithVersionNegotiation(t *testing.T) {
+	agentVersions := gocd.APIVersions{
+		gocd.AgentsEndpoint: {
+			{Accept: gocd.HeaderVersionSix, Until: "21.1.0"},
+			{Accept: gocd.HeaderVersionSeven, Since: "20.1.0"},
+		},
+	}
+
+	t.Run("should fall back to the version of the API served by the server, detecting its version once", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("19.3.0", &versionCalls, &accept)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithVersionNegotiation(agentVersions))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionSix, accept.Load())
+
+		_, err = client.GetAgent("agent-id")
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionSix, accept.Load())
+
+		_, err = client.GetPipelineGroups()
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionOne, accept.Load())
+
+		assert.Equal(t, int32(1), versionCalls.Load())
+	})
+
+	t.Run("should negotiate the versions of the API known to the SDK unless disabled", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("19.2.0", &versionCalls, &accept)
+		defer server.Close()
+
+		var unsupportedErr *errors.UnsupportedOnServerVersion
+
+		client, err := gocd.New(server.URL)
+		require.NoError(t, err)
+
+		_, err = client.GetClusterProfiles()
+		require.ErrorAs(t, err, &unsupportedErr)
+
+		_, err = gocd.NewClient(server.URL, auth, "info", nil).GetClusterProfiles()
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, int32(2), versionCalls.Load())
+
+		client, err = gocd.New(server.URL, gocd.WithoutVersionNegotiation())
+		require.NoError(t, err)
+
+		_, err = client.GetClusterProfiles()
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionOne, accept.Load())
+		assert.Equal(t, int32(2), versionCalls.Load())
+	})
+
+	t.Run("should pin the endpoints known to the SDK by default at the releases of GoCD introducing them", func(t *testing.T) {
+		boundaries := []struct {
+			call   func(client gocd.GoCd) error
+			before string
+			since  string
+		}{
+			{
+				call:   func(client gocd.GoCd) error { _, err := client.GetMaintenanceModeInfo(); return err },
+				before: "18.12.0", since: "19.1.0",
+			},
+			{
+				call:   func(client gocd.GoCd) error { _, err := client.GetClusterProfiles(); return err },
+				before: "19.2.0", since: "19.3.0",
+			},
+			{
+				call:   func(client gocd.GoCd) error { _, err := client.GetSecretConfigs(); return err },
+				before: "19.5.0", since: "19.6.0",
+			},
+		}
+
+		for _, boundary := range boundaries {
+			var versionCalls atomic.Int32
+
+			var accept atomic.Value
+
+			before := versionedServer(boundary.before, &versionCalls, &accept)
+			client, err := gocd.New(before.URL, gocd.WithVersionNegotiation(nil))
+			require.NoError(t, err)
+
+			var unsupportedErr *errors.UnsupportedOnServerVersion
+			require.ErrorAs(t, boundary.call(client), &unsupportedErr)
+			assert.Equal(t, boundary.since, unsupportedErr.Since)
+			before.Close()
+
+			since := versionedServer(boundary.since, &versionCalls, &accept)
+			client, err = gocd.New(since.URL, gocd.WithVersionNegotiation(nil))
+			require.NoError(t, err)
+
+			require.NoError(t, boundary.call(client))
+			since.Close()
+		}
+	})
+
+	t.Run("should use the version of the API set by the method when more than one of the versions listed are served", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("20.5.0", &versionCalls, &accept)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithVersionNegotiation(agentVersions))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionSeven, accept.Load())
+	})
+
+	t.Run("should use the version of the API set by the method when served by the server", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("23.1.0", &versionCalls, &accept)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithVersionNegotiation(agentVersions))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionSeven, accept.Load())
+	})
+
+	t.Run("should match the endpoints listed part by part, preferring the longest of them", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("19.3.0", &versionCalls, &accept)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL+"/go", gocd.WithVersionNegotiation(gocd.APIVersions{
+			gocd.AgentsEndpoint:        {{Accept: gocd.HeaderVersionSeven, Since: "20.1.0"}},
+			gocd.JobRunHistoryEndpoint: {{Accept: gocd.HeaderVersionOne, Since: "19.1.0"}},
+			"/api/admin/pipeline":      {{Accept: gocd.HeaderVersionOne, Since: "99.1.0"}},
+		}))
+		require.NoError(t, err)
+
+		_, err = client.GetAgentJobRunHistory("agent-id")
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionOne, accept.Load())
+
+		var unsupportedErr *errors.UnsupportedOnServerVersion
+
+		_, err = client.GetAgent("agent-id")
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, gocd.AgentsEndpoint, unsupportedErr.Endpoint)
+
+		_, err = client.GetAgents()
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, gocd.AgentsEndpoint, unsupportedErr.Endpoint)
+
+		_, err = client.GetPipelineGroups()
+		require.NoError(t, err)
+	})
+
+	t.Run("should error out when the method is not available on the version of the server", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("19.3.0", &versionCalls, &accept)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithVersionNegotiation(gocd.APIVersions{
+			gocd.VSMEndpoint: {{Accept: gocd.HeaderVersionZero, Until: "19.1.0"}},
+		}))
+		require.NoError(t, err)
+
+		_, err = client.GetSecretConfigs()
+		require.Error(t, err)
+
+		var unsupportedErr *errors.UnsupportedOnServerVersion
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, "GetSecretConfigs is not supported on GoCD server version 19.3.0, it is available since 19.6.0", unsupportedErr.Error())
+
+		_, err = client.GetPipelineVSM("helm-images", "20")
+		require.ErrorAs(t, err, &unsupportedErr)
+		assert.Equal(t, "GetPipelineVSM is not supported on GoCD server version 19.3.0, as it no longer serves '/pipelines/value_stream_map'",
+			unsupportedErr.Error())
+
+		assert.Nil(t, accept.Load())
+	})
+
+	t.Run("should call with the version of the API set by the method when the version of the server could not be detected", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("", &versionCalls, &accept)
+		defer server.Close()
+
+		policy := gocd.DefaultRetryPolicy()
+		policy.InitialInterval, policy.RetryOnStatus = time.Millisecond, []int{http.StatusInternalServerError}
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(policy), gocd.WithVersionNegotiation(agentVersions))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, gocd.HeaderVersionSeven, accept.Load())
+		assert.Equal(t, int32(1), versionCalls.Load(), "version should be detected without retries")
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), versionCalls.Load(), "version should not be detected again until the back-off passes")
+	})
+
+	t.Run("should detect the version of the server once for the concurrent calls", func(t *testing.T) {
+		var versionCalls atomic.Int32
+
+		var accept atomic.Value
+
+		server := versionedServer("19.3.0", &versionCalls, &accept)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithVersionNegotiation(agentVersions))
+		require.NoError(t, err)
+
+		var waitGroup sync.WaitGroup
+
+		for range 10 {
+			waitGroup.Add(1)
+
+			go func() {
+				defer waitGroup.Done()
+
+				_, agentsErr := client.GetAgents()
+				assert.NoError(t, agentsErr)
+			}()
+		}
+
+		waitGroup.Wait()
+
+		assert.Equal(t, int32(1), versionCalls.Load())
+		assert.Equal(t, gocd.HeaderVersionSix, accept.Load())
+	})
+}
@@ -0,0 +1,229 @@
+package gocd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	goCdLogger "github.com/nikhilsbhat/gocd-sdk-go/pkg/logger"
+	log "github.com/sirupsen/logrus"
+)
+
+// Option configures the client created by New.
+type Option func(opts *options) error
+
+// options holds all the configurations that could be set on the client using Option.
+type options struct {
+	auth           Auth
+	logLevel       string
+	logger         goCdLogger.Logger
+	debug          bool
+	caContent      [][]byte
+	systemRoots    bool
+	clientCerts    []tls.Certificate
+	insecure       bool
+	tlsConfig      *tls.Config
+	timeout        time.Duration
+	proxy          string
+	transport      http.RoundTripper
+	userAgent      string
+	retryPolicy    RetryPolicy
+	hooks          []Hooks
+	limit          *Limit
+	endpointLimits map[EndpointClass]Limit
+	cacheTTLs      map[string]time.Duration
+	apiVersions    APIVersions
+}
+
+// WithAuth sets the authorisation to be used by the client while talking to GoCD.
+func WithAuth(auth Auth) Option {
+	return func(opts *options) error {
+		opts.auth = auth
+
+		return nil
+	}
+}
+
+// WithLogLevel sets the log level of the logger used by the client, this is ignored when WithLogger or WithSlogLogger is set.
+func WithLogLevel(level string) Option {
+	return func(opts *options) error {
+		opts.logLevel = level
+
+		return nil
+	}
+}
+
+// WithLogger sets the logger to be used by the client instead of the default JSON logger.
+func WithLogger(logger *log.Logger) Option {
+	return func(opts *options) error {
+		if logger == nil {
+			return &errors.GoCDSDKError{Message: "logger cannot be nil"}
+		}
+
+		opts.logger = goCdLogger.NewLogrus(logger)
+
+		return nil
+	}
+}
+
+// WithSlogLogger routes all the logs of the client to the slog.Logger, instead of the default JSON logger.
+// The requests and responses are dumped at debug level when it is enabled on the logger, with the secrets redacted.
+func WithSlogLogger(logger *slog.Logger) Option {
+	return func(opts *options) error {
+		if logger == nil {
+			return &errors.GoCDSDKError{Message: "logger cannot be nil"}
+		}
+
+		opts.logger = goCdLogger.NewSlog(logger)
+		opts.debug = logger.Enabled(context.Background(), slog.LevelDebug)
+
+		return nil
+	}
+}
+
+// WithCACertificate adds the PEM encoded CA certificates to the pool used to verify the GoCD server.
+func WithCACertificate(caContent []byte) Option {
+	return func(opts *options) error {
+		if !x509.NewCertPool().AppendCertsFromPEM(caContent) {
+			return &errors.GoCDSDKError{Message: "no valid PEM encoded CA certificates found in the passed content"}
+		}
+
+		opts.caContent = append(opts.caContent, caContent)
+
+		return nil
+	}
+}
+
+// WithSystemRoots makes the client trust the CA certificates of the system along with ones set by WithCACertificate.
+func WithSystemRoots() Option {
+	return func(opts *options) error {
+		opts.systemRoots = true
+
+		return nil
+	}
+}
+
+// WithClientCertificate sets the PEM encoded certificate and key used by the client for mutual TLS.
+func WithClientCertificate(certPEM, keyPEM []byte) Option {
+	return func(opts *options) error {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return &errors.GoCDError{Message: "loading client certificate errored with", Err: err}
+		}
+
+		opts.clientCerts = append(opts.clientCerts, cert)
+
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of GoCD server's certificate, use it only when you know what you are doing.
+func WithInsecureSkipVerify() Option {
+	return func(opts *options) error {
+		opts.insecure = true
+
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration to be used by the client,
+// this takes precedence over WithCACertificate, WithSystemRoots, WithClientCertificate and WithInsecureSkipVerify.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(opts *options) error {
+		opts.tlsConfig = config
+
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request made to GoCD.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *options) error {
+		opts.timeout = timeout
+
+		return nil
+	}
+}
+
+// WithProxy sets the proxy through which the client talks to GoCD.
+func WithProxy(proxyURL string) Option {
+	return func(opts *options) error {
+		if _, err := url.Parse(proxyURL); err != nil {
+			return &errors.GoCDError{Message: "parsing proxy url errored with", Err: err}
+		}
+
+		opts.proxy = proxyURL
+
+		return nil
+	}
+}
+
+// WithTransport sets a custom http.RoundTripper to be used by the client.
+// TLS options are applied only when the transport passed is of type *http.Transport, to a clone of it.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(opts *options) error {
+		opts.transport = transport
+
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent on every request made to GoCD.
+func WithUserAgent(userAgent string) Option {
+	return func(opts *options) error {
+		opts.userAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithRetry sets the number of times a failed request is retried and the time to wait before the first retry,
+// the rest of the RetryPolicy set before it, or DefaultRetryPolicy, is retained.
+func WithRetry(count int, waitTime time.Duration) Option {
+	return func(opts *options) error {
+		opts.retryPolicy.MaxRetries = count
+		opts.retryPolicy.InitialInterval = waitTime
+
+		return nil
+	}
+}
+
+// getTLSConfig builds the tls.Config from the TLS options set.
+func (opts *options) getTLSConfig() (*tls.Config, error) {
+	if opts.tlsConfig != nil {
+		return opts.tlsConfig, nil
+	}
+
+	tlsConfig := &tls.Config{ //nolint:gosec
+		Certificates:       opts.clientCerts,
+		InsecureSkipVerify: opts.insecure, //nolint:gosec
+	}
+
+	if len(opts.caContent) == 0 {
+		return tlsConfig, nil
+	}
+
+	certPool := x509.NewCertPool()
+
+	if opts.systemRoots {
+		systemPool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, &errors.GoCDError{Message: "loading system cert pool errored with", Err: err}
+		}
+
+		certPool = systemPool
+	}
+
+	for _, caContent := range opts.caContent {
+		certPool.AppendCertsFromPEM(caContent)
+	}
+
+	tlsConfig.RootCAs = certPool
+
+	return tlsConfig, nil
+}
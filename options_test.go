@@ -0,0 +1,155 @@
+package gocd_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	calls int
+}
+
+func (transport *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.calls++
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNew(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(agentsJSON))
+	}))
+	defer tlsServer.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+
+	t.Run("should verify the server certificate by default", func(t *testing.T) {
+		client, err := gocd.New(tlsServer.URL, gocd.WithAuth(auth), gocd.WithRetry(0, time.Second))
+		require.NoError(t, err)
+
+		actual, err := client.GetAgents()
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "certificate signed by unknown authority"))
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should be able to skip server certificate verification when opted in", func(t *testing.T) {
+		client, err := gocd.New(tlsServer.URL, gocd.WithAuth(auth), gocd.WithInsecureSkipVerify())
+		require.NoError(t, err)
+
+		actual, err := client.GetAgents()
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+
+	t.Run("should be able to verify the server certificate with the CA passed", func(t *testing.T) {
+		client, err := gocd.New(tlsServer.URL, gocd.WithAuth(auth), gocd.WithCACertificate(serverCA), gocd.WithSystemRoots())
+		require.NoError(t, err)
+
+		actual, err := client.GetAgents()
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+
+	t.Run("should error out when the CA passed is not a valid PEM", func(t *testing.T) {
+		client, err := gocd.New(tlsServer.URL, gocd.WithCACertificate([]byte("invalid-ca")))
+		require.EqualError(t, err, "no valid PEM encoded CA certificates found in the passed content")
+		assert.Nil(t, client)
+	})
+
+	t.Run("should error out when the client certificate passed is not valid", func(t *testing.T) {
+		client, err := gocd.New(tlsServer.URL, gocd.WithClientCertificate([]byte("cert"), []byte("key")))
+		require.EqualError(t, err, "loading client certificate errored with tls: failed to find any PEM data in certificate input")
+		assert.Nil(t, client)
+	})
+
+	t.Run("should error out when the logger passed is nil", func(t *testing.T) {
+		client, err := gocd.New(tlsServer.URL, gocd.WithLogger(nil))
+		require.EqualError(t, err, "logger cannot be nil")
+		assert.Nil(t, client)
+	})
+
+	t.Run("should route the logs to the slog logger with the secrets redacted", func(t *testing.T) {
+		server := mockServer([]byte(mailServerJSON), http.StatusOK, map[string]string{"Accept": gocd.HeaderVersionOne}, false, nil)
+
+		var out bytes.Buffer
+
+		client, err := gocd.New(server.URL, gocd.WithAuth(auth),
+			gocd.WithSlogLogger(slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+		require.NoError(t, err)
+
+		_, err = client.CreateOrUpdateMailServerConfig(gocd.MailServerConfig{Hostname: "smtp.example.com", EncryptedPassword: "s3cr3t-value"})
+		require.NoError(t, err)
+
+		assert.Contains(t, out.String(), "level=DEBUG")
+		assert.Contains(t, out.String(), "smtp.example.com")
+		assert.NotContains(t, out.String(), "s3cr3t-value")
+		assert.NotContains(t, out.String(), base64.StdEncoding.EncodeToString([]byte(auth.UserName+":"+auth.Password)))
+	})
+
+	t.Run("should send the user agent set", func(t *testing.T) {
+		server := mockServer([]byte(agentsJSON), http.StatusOK,
+			map[string]string{"Accept": gocd.HeaderVersionSeven, "User-Agent": "gocd-sdk-go-test"}, false, nil)
+
+		client, err := gocd.New(server.URL, gocd.WithAuth(auth), gocd.WithUserAgent("gocd-sdk-go-test"))
+		require.NoError(t, err)
+
+		actual, err := client.GetAgents()
+		require.NoError(t, err)
+		assert.Len(t, actual, 1)
+	})
+
+	t.Run("should route the requests through the custom transport set", func(t *testing.T) {
+		server := mockServer([]byte(agentsJSON), http.StatusOK,
+			map[string]string{"Accept": gocd.HeaderVersionSeven}, false, nil)
+
+		transport := &countingTransport{}
+
+		client, err := gocd.New(server.URL, gocd.WithAuth(auth), gocd.WithTransport(transport))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, 1, transport.calls)
+	})
+
+	t.Run("should not apply the TLS and proxy options to the transport passed", func(t *testing.T) {
+		transport := &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12}}
+
+		_, err := gocd.New("https://gocd.example.com/go", gocd.WithAuth(auth), gocd.WithTransport(transport),
+			gocd.WithInsecureSkipVerify(), gocd.WithProxy("http://proxy.example.com:3128"))
+		require.NoError(t, err)
+
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+		assert.Nil(t, transport.Proxy)
+	})
+
+	t.Run("should error out when the server does not respond within the timeout set", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			time.Sleep(500 * time.Millisecond)
+			writer.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithAuth(auth), gocd.WithTimeout(50*time.Millisecond), gocd.WithRetry(0, time.Second))
+		require.NoError(t, err)
+
+		actual, err := client.GetAgents()
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "Client.Timeout exceeded"))
+		assert.Nil(t, actual)
+	})
+}
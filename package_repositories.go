@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetPackageRepositories() ([]PackageRepository, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var packageRepositoriesCfg PackageRepositories
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -39,14 +33,9 @@ func (conf *client) GetPackageRepositories() ([]PackageRepository, error) {
 }
 
 func (conf *client) GetPackageRepository(repoID string) (PackageRepository, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PackageRepository{}, err
-	}
-
 	var repositoryCfg PackageRepository
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -69,14 +58,9 @@ func (conf *client) GetPackageRepository(repoID string) (PackageRepository, erro
 }
 
 func (conf *client) CreatePackageRepository(config PackageRepository) (PackageRepository, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PackageRepository{}, err
-	}
-
 	var repositoryCfg PackageRepository
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -101,14 +85,9 @@ func (conf *client) CreatePackageRepository(config PackageRepository) (PackageRe
 }
 
 func (conf *client) UpdatePackageRepository(config PackageRepository) (PackageRepository, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PackageRepository{}, err
-	}
-
 	var repositoryCfg PackageRepository
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -134,12 +113,7 @@ func (conf *client) UpdatePackageRepository(config PackageRepository) (PackageRe
 }
 
 func (conf *client) DeletePackageRepository(repoID string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
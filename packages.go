@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetPackages() ([]Package, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var packagesCfg Packages
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -39,14 +33,9 @@ func (conf *client) GetPackages() ([]Package, error) {
 }
 
 func (conf *client) GetPackage(repoID string) (Package, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Package{}, err
-	}
-
 	var packageCfg Package
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -69,14 +58,9 @@ func (conf *client) GetPackage(repoID string) (Package, error) {
 }
 
 func (conf *client) CreatePackage(config Package) (Package, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Package{}, err
-	}
-
 	var packageCfg Package
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -101,14 +85,9 @@ func (conf *client) CreatePackage(config Package) (Package, error) {
 }
 
 func (conf *client) UpdatePackage(config Package) (Package, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Package{}, err
-	}
-
 	var packageCfg Package
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -134,12 +113,7 @@ func (conf *client) UpdatePackage(config Package) (Package, error) {
 }
 
 func (conf *client) DeletePackage(repoID string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -11,20 +11,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // GetPipelines fetches all pipelines configured in GoCD server.
 func (conf *client) GetPipelines() (PipelinesInfo, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PipelinesInfo{}, err
-	}
-
 	var pipelinesInfo PipelinesInfo
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		Get(APIFeedPipelineEndpoint)
 	if err != nil {
 		return PipelinesInfo{}, &errors.APIError{Err: err, Message: "get pipelines"}
@@ -43,14 +37,9 @@ func (conf *client) GetPipelines() (PipelinesInfo, error) {
 
 // GetPipelineState fetches status of selected pipelines.
 func (conf *client) GetPipelineState(pipeline string) (PipelineState, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PipelineState{}, err
-	}
-
 	var pipelinesStatus PipelineState
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -73,79 +62,48 @@ func (conf *client) GetPipelineState(pipeline string) (PipelineState, error) {
 }
 
 // GetPipelineRunHistory fetches all run history of selected pipeline from GoCD server.
-// This would be an expensive operation; make sure to run it during non-peak hours.
+// This would be an expensive operation; make sure to run it during non-peak hours,
+// or cap the calls made to the histories using WithEndpointLimit with EndpointClassFeed.
 func (conf *client) GetPipelineRunHistory(pipeline, pageSize string, delay time.Duration) ([]PipelineRunHistory, error) {
-	type runHistory struct {
-		Links     map[string]interface{} `json:"_links,omitempty" yaml:"_links,omitempty"`
-		Pipelines []PipelineRunHistory   `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
-	}
-
 	pipelineRunHistories := make([]PipelineRunHistory, 0)
 
 	after := "0"
 
 	for {
-		newClient := &client{}
-		if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-			return nil, err
-		}
-
-		var pipelineRunHistory runHistory
-
-		resp, err := newClient.httpClient.R().
-			SetHeaders(map[string]string{
-				"Accept":       HeaderVersionOne,
-				"Content-Type": ContentJSON,
-			}).
-			SetQueryParams(map[string]string{
-				"page_size": pageSize,
-				"after":     after,
-			}).
-			Get(filepath.Join(PipelinesEndpoint, pipeline, "history"))
+		pipelines, next, err := conf.getPipelineRunHistoryPage(pipeline, pageSize, after)
 		if err != nil {
-			return nil, &errors.APIError{Err: err, Message: fmt.Sprintf("get pipeline history for '%s'", pipeline)}
-		}
-
-		if resp.StatusCode() != http.StatusOK {
-			return nil, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
-		}
-
-		if err = json.Unmarshal(resp.Body(), &pipelineRunHistory); err != nil {
-			return nil, &errors.MarshalError{Err: err}
+			return nil, err
 		}
 
-		if nextLnk := pipelineRunHistory.Links["next"]; nextLnk == nil {
-			pipelineRunHistories = append(pipelineRunHistories, pipelineRunHistory.Pipelines...)
+		pipelineRunHistories = append(pipelineRunHistories, pipelines...)
 
+		if len(next) == 0 {
 			break
 		}
 
-		nextLink := pipelineRunHistory.Links["next"].(map[string]interface{})["href"].(string)
-		after = strings.Split(nextLink, "after=")[1]
+		after = next
 
-		pipelineRunHistories = append(pipelineRunHistories, pipelineRunHistory.Pipelines...)
-
-		time.Sleep(delay)
+		select {
+		case <-conf.getContext().Done():
+			return nil, &errors.APIError{Err: conf.getContext().Err(), Message: fmt.Sprintf("get pipeline history for '%s'", pipeline)}
+		case <-time.After(delay):
+		}
 	}
 
 	return pipelineRunHistories, nil
 }
 
 // GetLimitedPipelineRunHistory fetches a limited run history of selected pipeline from GoCD server.
-// This would be an expensive operation; make sure to run it during non-peak hours.
+// This would be an expensive operation; make sure to run it during non-peak hours,
+// or cap the calls made to the histories using WithEndpointLimit with EndpointClassFeed.
 func (conf *client) GetLimitedPipelineRunHistory(pipeline, pageSize, after string) ([]PipelineRunHistory, error) {
 	type runHistory struct {
 		Pipelines []PipelineRunHistory `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
 	}
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var pipelineRunHistory runHistory
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -172,14 +130,9 @@ func (conf *client) GetLimitedPipelineRunHistory(pipeline, pageSize, after strin
 
 // GetPipelineSchedules fetches the last X schedules of the selected pipeline from GoCD server.
 func (conf *client) GetPipelineSchedules(pipeline, start, perPage string) (PipelineSchedules, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PipelineSchedules{}, err
-	}
-
 	var pipelineSchedules PipelineSchedules
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionZero,
 			"Content-Type": ContentJSON,
@@ -206,17 +159,12 @@ func (conf *client) GetPipelineSchedules(pipeline, start, perPage string) (Pipel
 
 // PipelinePause pauses specified pipeline with valid message passed.
 func (conf *client) PipelinePause(name string, message any) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
 	msg := "pausing pipeline " + name
 	if message != nil {
 		msg = message.(string)
 	}
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -238,12 +186,7 @@ func (conf *client) PipelinePause(name string, message any) error {
 
 // PipelineUnPause unpauses specified pipeline.
 func (conf *client) PipelineUnPause(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionOne,
 			HeaderConfirm: "true",
@@ -262,12 +205,7 @@ func (conf *client) PipelineUnPause(name string) error {
 
 // PipelineUnlock unlocks the specified locked pipeline.
 func (conf *client) PipelineUnlock(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionOne,
 			HeaderConfirm: "true",
@@ -286,12 +224,7 @@ func (conf *client) PipelineUnlock(name string) error {
 
 // SchedulePipeline schedules the specified pipeline with specified configurations.
 func (conf *client) SchedulePipeline(name string, schedule Schedule) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -325,12 +258,7 @@ func (conf *client) CommentOnPipeline(comment PipelineObject) error {
 		return &errors.GoCDError{Message: "comment message cannot be empty"}
 	}
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -352,12 +280,7 @@ func (conf *client) CommentOnPipeline(comment PipelineObject) error {
 func (conf *client) GetPipelineInstance(pipeline PipelineObject) (map[string]interface{}, error) {
 	var pipelineInstance map[string]interface{}
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return pipelineInstance, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -378,12 +301,7 @@ func (conf *client) GetPipelineInstance(pipeline PipelineObject) (map[string]int
 }
 
 func (conf *client) ExportPipelineToConfigRepoFormat(pipelineName, pluginID string) (PipelineExport, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PipelineExport{}, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -410,3 +328,40 @@ func (conf *client) ExportPipelineToConfigRepoFormat(pipelineName, pluginID stri
 
 	return pipelineExport, nil
 }
+
+// getPipelineRunHistoryPage fetches a page of run history of the pipeline along with the cursor to the next page,
+// cursor would be empty when it is the last page.
+func (conf *client) getPipelineRunHistoryPage(pipeline, pageSize, after string) ([]PipelineRunHistory, string, error) {
+	type runHistory struct {
+		Links     pageLinks            `json:"_links,omitempty" yaml:"_links,omitempty"`
+		Pipelines []PipelineRunHistory `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+	}
+
+	queryParams := map[string]string{"page_size": pageSize}
+	if len(after) != 0 {
+		queryParams["after"] = after
+	}
+
+	var pipelineRunHistory runHistory
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept":       HeaderVersionOne,
+			"Content-Type": ContentJSON,
+		}).
+		SetQueryParams(queryParams).
+		Get(filepath.Join(PipelinesEndpoint, pipeline, "history"))
+	if err != nil {
+		return nil, "", &errors.APIError{Err: err, Message: fmt.Sprintf("get pipeline history for '%s'", pipeline)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, "", &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &pipelineRunHistory); err != nil {
+		return nil, "", &errors.MarshalError{Err: err}
+	}
+
+	return pipelineRunHistory.Pipelines, pipelineRunHistory.Links.Next.cursor("after"), nil
+}
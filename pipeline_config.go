@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetPipelineConfig(name string) (PipelineConfig, error) {
 	var pipelineConfig PipelineConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return pipelineConfig, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionEleven,
 		}).
@@ -43,12 +37,7 @@ func (conf *client) GetPipelineConfig(name string) (PipelineConfig, error) {
 func (conf *client) UpdatePipelineConfig(config PipelineConfig) (PipelineConfig, error) {
 	var pipelineConfig PipelineConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return pipelineConfig, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionEleven,
 			"Content-Type": ContentJSON,
@@ -76,11 +65,6 @@ func (conf *client) UpdatePipelineConfig(config PipelineConfig) (PipelineConfig,
 func (conf *client) CreatePipeline(config PipelineConfig) (PipelineConfig, error) {
 	var pipelineConfig PipelineConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PipelineConfig{}, err
-	}
-
 	defaultHeaders := map[string]string{
 		"Accept":       HeaderVersionEleven,
 		"Content-Type": ContentJSON,
@@ -94,7 +78,7 @@ func (conf *client) CreatePipeline(config PipelineConfig) (PipelineConfig, error
 		defaultHeaders["X-pause-cause"] = config.CreateOptions.PauseReason
 	}
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(defaultHeaders).
 		SetBody(config).
 		Post(PipelineConfigEndpoint)
@@ -116,12 +100,7 @@ func (conf *client) CreatePipeline(config PipelineConfig) (PipelineConfig, error
 }
 
 func (conf *client) DeletePipeline(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionEleven,
 		}).
@@ -140,12 +119,7 @@ func (conf *client) DeletePipeline(name string) error {
 func (conf *client) ExtractTemplatePipeline(pipeline, template string) (PipelineConfig, error) {
 	var pipelineConfig PipelineConfig
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return pipelineConfig, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionEleven,
 			"Content-Type": ContentJSON,
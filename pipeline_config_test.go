@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -660,6 +661,25 @@ func Test_client_CreatePipeline(t *testing.T) {
 		require.EqualError(t, err, "reading response body errored with: invalid character 'p' looking for beginning of value")
 	})
 
+	t.Run("should be able to inspect the field wise validation errors while creating pipeline configuration", func(t *testing.T) {
+		server := mockServer([]byte(`{"message": "Validations failed for pipeline 'new_pipeline'.", `+
+			`"data": {"name": "new_pipeline", "errors": {"group": ["Pipeline group must be specified"]}}}`),
+			http.StatusUnprocessableEntity, correctPipelineConfigHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		input := gocd.PipelineConfig{
+			Name: "new_pipeline",
+		}
+
+		_, err := client.CreatePipeline(input)
+		require.Error(t, err)
+		assert.True(t, errors.IsUnprocessable(err))
+
+		validationErr, ok := errors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, map[string][]string{"group": {"Pipeline group must be specified"}}, validationErr.Fields)
+	})
+
 	t.Run("should error out while creating pipeline configuration present in GoCD as server is not reachable", func(t *testing.T) {
 		client := gocd.NewClient("http://localhost:8156/go", auth, "info", nil)
 
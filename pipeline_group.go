@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
@@ -15,12 +14,7 @@ type Groups []PipelineGroup
 
 // CreatePipelineGroup will create pipeline group with provided configurations.
 func (conf *client) CreatePipelineGroup(group PipelineGroup) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			HeaderConfirm:  "true",
 			"Accept":       HeaderVersionOne,
@@ -41,14 +35,9 @@ func (conf *client) CreatePipelineGroup(group PipelineGroup) error {
 
 // GetPipelineGroups fetches information of backup configured in GoCD server.
 func (conf *client) GetPipelineGroups() ([]PipelineGroup, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var groupConf PipelineGroupsConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -80,12 +69,7 @@ func (conf *client) GetPipelineGroups() ([]PipelineGroup, error) {
 
 // DeletePipelineGroup deletes the specified pipeline group present in GoCD.
 func (conf *client) DeletePipelineGroup(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -115,12 +99,7 @@ func (conf Groups) Count() int {
 func (conf *client) GetPipelineGroup(name string) (PipelineGroup, error) {
 	var pipelineGroup PipelineGroup
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return pipelineGroup, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -146,12 +125,7 @@ func (conf *client) GetPipelineGroup(name string) (PipelineGroup, error) {
 func (conf *client) UpdatePipelineGroup(group PipelineGroup) (PipelineGroup, error) {
 	var pipelineGroup PipelineGroup
 
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return pipelineGroup, err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
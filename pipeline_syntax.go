@@ -12,7 +12,7 @@ func (conf *client) ValidatePipelineSyntax(pluginCfg plugin.Plugin, pipelines []
 	}
 
 	if fetchVersionFromServer {
-		conf.logger.Info("since fetch version from server is enabled, fetching the plugin version from GoCD server")
+		conf.logger.Infof("since fetch version from server is enabled, fetching the plugin version from GoCD server")
 
 		pluginsInfo, err := conf.GetPluginsInfo()
 		if err != nil {
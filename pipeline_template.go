@@ -0,0 +1,219 @@
+package gocd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// GetTemplates fetches all pipeline templates present in GoCD along with the pipelines using them.
+func (conf *client) GetTemplates() ([]PipelineTemplateConfig, error) {
+	type templatePipelines struct {
+		Pipelines []Pipeline `json:"pipelines,omitempty"`
+	}
+
+	type template struct {
+		Name     string            `json:"name,omitempty"`
+		Embedded templatePipelines `json:"_embedded,omitempty"`
+	}
+
+	type templatesConfig struct {
+		Embedded struct {
+			Templates []template `json:"templates,omitempty"`
+		} `json:"_embedded,omitempty"`
+	}
+
+	var templatesCfg templatesConfig
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionSeven,
+		}).
+		Get(TemplatesEndpoint)
+	if err != nil {
+		return nil, &errors.APIError{Err: err, Message: "get pipeline templates"}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &templatesCfg); err != nil {
+		return nil, &errors.MarshalError{Err: err}
+	}
+
+	templates := make([]PipelineTemplateConfig, 0)
+
+	for _, templateCfg := range templatesCfg.Embedded.Templates {
+		pipelines := make([]string, 0)
+		for _, pipeline := range templateCfg.Embedded.Pipelines {
+			pipelines = append(pipelines, pipeline.Name)
+		}
+
+		templates = append(templates, PipelineTemplateConfig{
+			Name:      templateCfg.Name,
+			Pipelines: pipelines,
+		})
+	}
+
+	return templates, nil
+}
+
+// GetTemplate fetches the configuration of the specified pipeline template.
+func (conf *client) GetTemplate(name string) (PipelineTemplateConfig, error) {
+	var templateCfg PipelineTemplateConfig
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionSeven,
+		}).
+		Get(filepath.Join(TemplatesEndpoint, name))
+	if err != nil {
+		return templateCfg, &errors.APIError{Err: err, Message: fmt.Sprintf("get pipeline template '%s'", name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return templateCfg, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &templateCfg); err != nil {
+		return templateCfg, &errors.MarshalError{Err: err}
+	}
+
+	templateCfg.ETAG = resp.Header().Get("ETag")
+
+	return templateCfg, nil
+}
+
+// CreateTemplate creates the pipeline template with the passed configuration.
+func (conf *client) CreateTemplate(config PipelineTemplateConfig) (PipelineTemplateConfig, error) {
+	var templateCfg PipelineTemplateConfig
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept":       HeaderVersionSeven,
+			"Content-Type": ContentJSON,
+		}).
+		SetBody(config).
+		Post(TemplatesEndpoint)
+	if err != nil {
+		return templateCfg, &errors.APIError{Err: err, Message: fmt.Sprintf("create pipeline template '%s'", config.Name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return templateCfg, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &templateCfg); err != nil {
+		return templateCfg, &errors.MarshalError{Err: err}
+	}
+
+	templateCfg.ETAG = resp.Header().Get("ETag")
+
+	return templateCfg, nil
+}
+
+// UpdateTemplate updates the pipeline template with the passed configuration, ETAG of the template is mandatory.
+func (conf *client) UpdateTemplate(config PipelineTemplateConfig) (PipelineTemplateConfig, error) {
+	var templateCfg PipelineTemplateConfig
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept":       HeaderVersionSeven,
+			"Content-Type": ContentJSON,
+			"If-Match":     config.ETAG,
+		}).
+		SetBody(config).
+		Put(filepath.Join(TemplatesEndpoint, config.Name))
+	if err != nil {
+		return templateCfg, &errors.APIError{Err: err, Message: fmt.Sprintf("update pipeline template '%s'", config.Name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return templateCfg, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &templateCfg); err != nil {
+		return templateCfg, &errors.MarshalError{Err: err}
+	}
+
+	templateCfg.ETAG = resp.Header().Get("ETag")
+
+	return templateCfg, nil
+}
+
+// DeleteTemplate deletes the specified pipeline template, GoCD would not allow deleting a template that is in use.
+func (conf *client) DeleteTemplate(name string) error {
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionSeven,
+		}).
+		Delete(filepath.Join(TemplatesEndpoint, name))
+	if err != nil {
+		return &errors.APIError{Err: err, Message: fmt.Sprintf("delete pipeline template '%s'", name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	return nil
+}
+
+// GetTemplateAuthorization fetches the users and roles authorized to administer and view the specified pipeline template.
+func (conf *client) GetTemplateAuthorization(name string) (TemplateAuthorization, error) {
+	var authorization TemplateAuthorization
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionOne,
+		}).
+		Get(filepath.Join(TemplatesEndpoint, name, "authorization"))
+	if err != nil {
+		return authorization, &errors.APIError{Err: err, Message: fmt.Sprintf("get authorization of pipeline template '%s'", name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return authorization, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &authorization); err != nil {
+		return authorization, &errors.MarshalError{Err: err}
+	}
+
+	authorization.ETAG = resp.Header().Get("ETag")
+
+	return authorization, nil
+}
+
+// UpdateTemplateAuthorization updates the users and roles authorized to administer and view the specified pipeline template.
+func (conf *client) UpdateTemplateAuthorization(name string, authorization TemplateAuthorization) (TemplateAuthorization, error) {
+	var authorizationCfg TemplateAuthorization
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept":       HeaderVersionOne,
+			"Content-Type": ContentJSON,
+			"If-Match":     authorization.ETAG,
+		}).
+		SetBody(authorization).
+		Put(filepath.Join(TemplatesEndpoint, name, "authorization"))
+	if err != nil {
+		return authorizationCfg, &errors.APIError{Err: err, Message: fmt.Sprintf("update authorization of pipeline template '%s'", name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return authorizationCfg, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &authorizationCfg); err != nil {
+		return authorizationCfg, &errors.MarshalError{Err: err}
+	}
+
+	authorizationCfg.ETAG = resp.Header().Get("ETag")
+
+	return authorizationCfg, nil
+}
@@ -0,0 +1,209 @@
+package gocd_test
+
+import (
+	_ "embed"
+	"net/http"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	//go:embed internal/fixtures/templates.json
+	templatesJSON string
+	//go:embed internal/fixtures/template.json
+	templateJSON string
+	//go:embed internal/fixtures/template_authorization.json
+	templateAuthorizationJSON string
+)
+
+func Test_client_GetTemplates(t *testing.T) {
+	correctTemplateHeader := map[string]string{"Accept": gocd.HeaderVersionSeven}
+
+	t.Run("should be able to fetch all pipeline templates along with the pipelines using it", func(t *testing.T) {
+		server := mockServer([]byte(templatesJSON), http.StatusOK, correctTemplateHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		expected := []gocd.PipelineTemplateConfig{
+			{Name: "template1", Pipelines: []string{"up42", "up43"}},
+			{Name: "template2", Pipelines: []string{}},
+		}
+
+		actual, err := client.GetTemplates()
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should error out while fetching pipeline templates as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("templatesJSON"), http.StatusBadGateway, correctTemplateHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetTemplates()
+		require.EqualError(t, err, "got 502 from GoCD while making GET call for "+server.URL+
+			"/api/admin/templates\nwith BODY:templatesJSON")
+		assert.Nil(t, actual)
+	})
+
+	t.Run("should error out while fetching pipeline templates as server returned malformed response", func(t *testing.T) {
+		server := mockServer([]byte(`{"_embedded"}`), http.StatusOK, correctTemplateHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetTemplates()
+		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
+		assert.Nil(t, actual)
+	})
+}
+
+func Test_client_GetTemplate(t *testing.T) {
+	correctTemplateHeader := map[string]string{"Accept": gocd.HeaderVersionSeven}
+
+	t.Run("should be able to fetch the specified pipeline template", func(t *testing.T) {
+		server := mockServer([]byte(templateJSON), http.StatusOK, correctTemplateHeader,
+			false, map[string]string{"ETag": "cbc5f2d5b9c13a2cc1b1efb3d8a6155d"})
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetTemplate("template1")
+		require.NoError(t, err)
+		assert.Equal(t, "template1", actual.Name)
+		assert.Equal(t, "cbc5f2d5b9c13a2cc1b1efb3d8a6155d", actual.ETAG)
+		assert.Len(t, actual.Stages, 1)
+		assert.Equal(t, "up42_job", actual.Stages[0].Jobs[0].Name)
+	})
+
+	t.Run("should error out while fetching pipeline template as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte(templateJSON), http.StatusOK, map[string]string{"Accept": gocd.HeaderVersionTwo}, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetTemplate("template1")
+		require.EqualError(t, err, "got 404 from GoCD while making GET call for "+server.URL+
+			"/api/admin/templates/template1\nwith BODY:<html>\n<body>\n\t<h2>404 Not found</h2>\n</body>\n\n</html>")
+		assert.Equal(t, gocd.PipelineTemplateConfig{}, actual)
+	})
+}
+
+func Test_client_CreateTemplate(t *testing.T) {
+	correctTemplateHeader := map[string]string{"Accept": gocd.HeaderVersionSeven, "Content-Type": gocd.ContentJSON}
+
+	t.Run("should be able to create the pipeline template", func(t *testing.T) {
+		server := mockServer([]byte(templateJSON), http.StatusOK, correctTemplateHeader,
+			false, map[string]string{"ETag": "cbc5f2d5b9c13a2cc1b1efb3d8a6155d"})
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.CreateTemplate(gocd.PipelineTemplateConfig{
+			Name:   "template1",
+			Stages: []gocd.PipelineStageConfig{{Name: "up42_stage"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "template1", actual.Name)
+		assert.Equal(t, "cbc5f2d5b9c13a2cc1b1efb3d8a6155d", actual.ETAG)
+	})
+
+	t.Run("should error out while creating the pipeline template as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("templateJSON"), http.StatusUnprocessableEntity, correctTemplateHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.CreateTemplate(gocd.PipelineTemplateConfig{Name: "template1"})
+		require.EqualError(t, err, "got 422 from GoCD while making POST call for "+server.URL+
+			"/api/admin/templates\nwith BODY:templateJSON")
+		assert.Equal(t, gocd.PipelineTemplateConfig{}, actual)
+	})
+}
+
+func Test_client_UpdateTemplate(t *testing.T) {
+	t.Run("should be able to update the pipeline template", func(t *testing.T) {
+		server := mockServer([]byte(templateJSON), http.StatusOK, map[string]string{
+			"Accept":       gocd.HeaderVersionSeven,
+			"Content-Type": gocd.ContentJSON,
+			"If-Match":     "cbc5f2d5b9c13a2cc1b1efb3d8a6155d",
+		}, false, map[string]string{"ETag": "61406622382e51c2079c11dcbdb978fb"})
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.UpdateTemplate(gocd.PipelineTemplateConfig{
+			Name: "template1",
+			ETAG: "cbc5f2d5b9c13a2cc1b1efb3d8a6155d",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "61406622382e51c2079c11dcbdb978fb", actual.ETAG)
+	})
+
+	t.Run("should error out while updating the pipeline template when ETAG is not passed", func(t *testing.T) {
+		server := mockServer([]byte(templateJSON), http.StatusOK, map[string]string{
+			"Accept":       gocd.HeaderVersionSeven,
+			"Content-Type": gocd.ContentJSON,
+			"If-Match":     "cbc5f2d5b9c13a2cc1b1efb3d8a6155d",
+		}, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.UpdateTemplate(gocd.PipelineTemplateConfig{Name: "template1"})
+		require.EqualError(t, err, "got 404 from GoCD while making PUT call for "+server.URL+
+			"/api/admin/templates/template1\nwith BODY:<html>\n<body>\n\t<h2>404 Not found</h2>\n</body>\n\n</html>")
+		assert.Equal(t, gocd.PipelineTemplateConfig{}, actual)
+	})
+}
+
+func Test_client_DeleteTemplate(t *testing.T) {
+	t.Run("should be able to delete the pipeline template", func(t *testing.T) {
+		server := mockServer([]byte(`{"message": "The template 'template1' was deleted successfully."}`), http.StatusOK,
+			map[string]string{"Accept": gocd.HeaderVersionSeven}, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.DeleteTemplate("template1")
+		require.NoError(t, err)
+	})
+
+	t.Run("should error out while deleting the pipeline template as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("templateJSON"), http.StatusUnprocessableEntity,
+			map[string]string{"Accept": gocd.HeaderVersionSeven}, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.DeleteTemplate("template1")
+		require.EqualError(t, err, "got 422 from GoCD while making DELETE call for "+server.URL+
+			"/api/admin/templates/template1\nwith BODY:templateJSON")
+	})
+}
+
+func Test_client_TemplateAuthorization(t *testing.T) {
+	expected := gocd.TemplateAuthorization{
+		AllGroupAdminsAreViewUsers: true,
+		Admin:                      gocd.AuthorizationConfig{Roles: []string{"admin"}, Users: []string{"alice"}},
+		View:                       gocd.AuthorizationConfig{Roles: []string{}, Users: []string{"bob"}},
+		ETAG:                       "cbc5f2d5b9c13a2cc1b1efb3d8a6155d",
+	}
+
+	t.Run("should be able to fetch the authorization of the pipeline template", func(t *testing.T) {
+		server := mockServer([]byte(templateAuthorizationJSON), http.StatusOK, map[string]string{"Accept": gocd.HeaderVersionOne},
+			false, map[string]string{"ETag": "cbc5f2d5b9c13a2cc1b1efb3d8a6155d"})
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetTemplateAuthorization("template1")
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should be able to update the authorization of the pipeline template", func(t *testing.T) {
+		server := mockServer([]byte(templateAuthorizationJSON), http.StatusOK, map[string]string{
+			"Accept":       gocd.HeaderVersionOne,
+			"Content-Type": gocd.ContentJSON,
+			"If-Match":     "61406622382e51c2079c11dcbdb978fb",
+		}, false, map[string]string{"ETag": "cbc5f2d5b9c13a2cc1b1efb3d8a6155d"})
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		input := expected
+		input.ETAG = "61406622382e51c2079c11dcbdb978fb"
+
+		actual, err := client.UpdateTemplateAuthorization("template1", input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should error out while fetching the authorization of the pipeline template as server returned malformed response", func(t *testing.T) {
+		server := mockServer([]byte(`{"admin"}`), http.StatusOK, map[string]string{"Accept": gocd.HeaderVersionOne}, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetTemplateAuthorization("template1")
+		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
+		assert.Equal(t, gocd.TemplateAuthorization{}, actual)
+	})
+}
@@ -1,6 +1,7 @@
 package gocd_test
 
 import (
+	"context"
 	_ "embed"
 	"net/http"
 	"testing"
@@ -26,6 +27,8 @@ var (
 	pipelineExtractionJSON string
 	//go:embed internal/fixtures/pipeline_history.json
 	pipelineRunHistory string
+	//go:embed internal/fixtures/pipeline_history_one.json
+	pipelineRunHistoryPageOne string
 )
 
 var pipelineMap = map[string]interface{}{
@@ -219,6 +222,19 @@ func Test_client_GetPipelineHistory(t *testing.T) {
 		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
 		assert.Nil(t, actual)
 	})
+
+	t.Run("should stop paginating pipeline run history when the context is cancelled", func(t *testing.T) {
+		server := mockServer([]byte(pipelineRunHistoryPageOne), http.StatusOK, nil, true, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		client := gocd.NewClient(server.URL, auth, "info", nil).WithContext(ctx)
+
+		actual, err := client.GetPipelineRunHistory("pipeline1", "10", time.Duration(10)*time.Second)
+		require.EqualError(t, err, "call made to get pipeline history for 'pipeline1' errored with: context deadline exceeded")
+		assert.Nil(t, actual)
+	})
 }
 
 func Test_client_GetLimitedPipelineRunHistory(t *testing.T) {
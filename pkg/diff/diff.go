@@ -0,0 +1,317 @@
+// Package diff compares two instances of the gocd-sdk-go types and reports what changed between them.
+//
+// The objects are compared on their JSON representation, so the changes are addressed by the paths made of JSON
+// field names, e.g. 'stages[name=build].jobs[name=test].timeout'. Elements of the lists of objects identified by
+// name, id or key are matched by it, other lists are compared by index.
+// Volatile fields like ETAG and _links are ignored.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// Type is the type of change made to the value at a path.
+type Type string
+
+const (
+	Added    Type = "added"
+	Removed  Type = "removed"
+	Modified Type = "modified"
+)
+
+// Change is a change made to the value at Path, Old is nil when the value was Added and New is nil when it was Removed.
+type Change struct {
+	Path string `json:"path" yaml:"path"`
+	Type Type   `json:"type" yaml:"type"`
+	Old  any    `json:"old,omitempty" yaml:"old,omitempty"`
+	New  any    `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Changes holds all the changes between two objects, in the order of the fields compared.
+type Changes []Change
+
+// Option configures the comparison.
+type Option func(*comparer)
+
+type comparer struct {
+	ignored map[string]bool
+	changes Changes
+}
+
+// defaultIgnoredFields are the fields that change without the object being changed.
+var defaultIgnoredFields = []string{"etag", "_links"}
+
+// identityFields are the fields used to match the elements of the lists of objects, in the order of preference.
+var identityFields = []string{"name", "id", "uuid", "login_name", "plugin_id", "key"}
+
+// IgnoreFields ignores the fields with the JSON names at any level, in addition to etag and _links.
+func IgnoreFields(names ...string) Option {
+	return func(cmp *comparer) {
+		for _, name := range names {
+			cmp.ignored[name] = true
+		}
+	}
+}
+
+// Compare returns the changes made to the object before to get the object after.
+func Compare[T any](before, after T, opts ...Option) (Changes, error) {
+	cmp := &comparer{ignored: make(map[string]bool), changes: make(Changes, 0)}
+	for _, field := range defaultIgnoredFields {
+		cmp.ignored[field] = true
+	}
+
+	for _, opt := range opts {
+		opt(cmp)
+	}
+
+	beforeValue, err := toValue(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterValue, err := toValue(after)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp.compare("", cmp.strip(beforeValue), cmp.strip(afterValue))
+
+	return cmp.changes, nil
+}
+
+func (cmp *comparer) compare(path string, before, after any) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		cmp.changes = append(cmp.changes, Change{Path: path, Type: Added, New: after})
+
+		return
+	case after == nil:
+		cmp.changes = append(cmp.changes, Change{Path: path, Type: Removed, Old: before})
+
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+
+	if beforeIsMap && afterIsMap {
+		cmp.compareMaps(path, beforeMap, afterMap)
+
+		return
+	}
+
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+
+	if beforeIsList && afterIsList {
+		cmp.compareLists(path, beforeList, afterList)
+
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		cmp.changes = append(cmp.changes, Change{Path: path, Type: Modified, Old: before, New: after})
+	}
+}
+
+func (cmp *comparer) compareMaps(path string, before, after map[string]any) {
+	keys := make([]string, 0, len(before)+len(after))
+
+	for key := range before {
+		keys = append(keys, key)
+	}
+
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := key
+		if len(path) != 0 {
+			childPath = path + "." + key
+		}
+
+		cmp.compare(childPath, before[key], after[key])
+	}
+}
+
+func (cmp *comparer) compareLists(path string, before, after []any) {
+	if field := identityField(before, after); len(field) != 0 {
+		cmp.compareListsByIdentity(path, field, before, after)
+
+		return
+	}
+
+	for index := 0; index < max(len(before), len(after)); index++ {
+		var beforeElement, afterElement any
+
+		if index < len(before) {
+			beforeElement = before[index]
+		}
+
+		if index < len(after) {
+			afterElement = after[index]
+		}
+
+		cmp.compare(fmt.Sprintf("%s[%d]", path, index), beforeElement, afterElement)
+	}
+}
+
+// compareListsByIdentity matches the elements of the lists by the identity field, the removed elements are reported
+// in the order of the before list followed by the changed and added elements in the order of the after list.
+func (cmp *comparer) compareListsByIdentity(path, field string, before, after []any) {
+	identity := func(element any) string {
+		return fmt.Sprintf("%v", element.(map[string]any)[field])
+	}
+
+	afterByIdentity := make(map[string]any, len(after))
+	for _, element := range after {
+		afterByIdentity[identity(element)] = element
+	}
+
+	beforeByIdentity := make(map[string]any, len(before))
+
+	for _, element := range before {
+		beforeByIdentity[identity(element)] = element
+
+		if _, ok := afterByIdentity[identity(element)]; !ok {
+			cmp.compare(fmt.Sprintf("%s[%s=%s]", path, field, identity(element)), element, nil)
+		}
+	}
+
+	for _, element := range after {
+		cmp.compare(fmt.Sprintf("%s[%s=%s]", path, field, identity(element)), beforeByIdentity[identity(element)], element)
+	}
+}
+
+// strip drops the ignored fields at all levels of the value, so that they are not reported in added or removed values either.
+func (cmp *comparer) strip(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, child := range typed {
+			if cmp.ignored[key] {
+				delete(typed, key)
+
+				continue
+			}
+
+			typed[key] = cmp.strip(child)
+		}
+	case []any:
+		for index, child := range typed {
+			typed[index] = cmp.strip(child)
+		}
+	}
+
+	return value
+}
+
+// identityField returns the field that identifies the elements of both the lists uniquely, empty when there is none.
+func identityField(lists ...[]any) string {
+	for _, field := range identityFields {
+		if identifiesAll(field, lists...) {
+			return field
+		}
+	}
+
+	return ""
+}
+
+func identifiesAll(field string, lists ...[]any) bool {
+	for _, list := range lists {
+		seen := make(map[string]bool, len(list))
+
+		for _, element := range list {
+			object, ok := element.(map[string]any)
+			if !ok {
+				return false
+			}
+
+			value, ok := object[field]
+			if !ok {
+				return false
+			}
+
+			identity := fmt.Sprintf("%v", value)
+			if seen[identity] {
+				return false
+			}
+
+			seen[identity] = true
+		}
+	}
+
+	return true
+}
+
+// Empty reports whether the objects compared are same.
+func (changes Changes) Empty() bool {
+	return len(changes) == 0
+}
+
+// String renders the changes in a human-readable form, one change per line.
+func (changes Changes) String() string {
+	lines := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		switch change.Type {
+		case Added:
+			lines = append(lines, fmt.Sprintf("+ %s: %s", change.Path, render(change.New)))
+		case Removed:
+			lines = append(lines, fmt.Sprintf("- %s: %s", change.Path, render(change.Old)))
+		case Modified:
+			lines = append(lines, fmt.Sprintf("~ %s: %s => %s", change.Path, render(change.Old), render(change.New)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// JSON renders the changes as JSON array, an empty array when there are no changes.
+func (changes Changes) JSON() ([]byte, error) {
+	if changes == nil {
+		changes = make(Changes, 0)
+	}
+
+	out, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return nil, &errors.GoCDError{Message: "rendering changes as JSON errored with", Err: err}
+	}
+
+	return out, nil
+}
+
+func render(value any) string {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(out)
+}
+
+func toValue(object any) (any, error) {
+	content, err := json.Marshal(object)
+	if err != nil {
+		return nil, &errors.GoCDError{Message: "encoding object to compare errored with", Err: err}
+	}
+
+	var value any
+	if err = json.Unmarshal(content, &value); err != nil {
+		return nil, &errors.GoCDError{Message: "decoding object to compare errored with", Err: err}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,91 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("should report the changes to environment addressed by path ignoring ETag", func(t *testing.T) {
+		before := gocd.Environment{
+			Name:      "staging",
+			Pipelines: []gocd.Pipeline{{Name: "up42"}, {Name: "up43"}},
+			EnvVars:   []gocd.EnvVars{{Name: "REGION", Value: "us-east-1"}, {Name: "DEBUG", Value: "true"}},
+			ETAG:      "etag-1",
+		}
+		after := gocd.Environment{
+			Name:      "staging",
+			Pipelines: []gocd.Pipeline{{Name: "up43"}, {Name: "up44"}},
+			EnvVars:   []gocd.EnvVars{{Name: "REGION", Value: "eu-west-1"}, {Name: "DEBUG", Value: "true"}},
+			ETAG:      "etag-2",
+		}
+
+		changes, err := diff.Compare(before, after)
+		require.NoError(t, err)
+
+		assert.Equal(t, diff.Changes{
+			{Path: "environment_variables[name=REGION].value", Type: diff.Modified, Old: "us-east-1", New: "eu-west-1"},
+			{Path: "pipelines[name=up42]", Type: diff.Removed, Old: map[string]any{"name": "up42"}},
+			{Path: "pipelines[name=up44]", Type: diff.Added, New: map[string]any{"name": "up44"}},
+		}, changes)
+
+		assert.Equal(t, `~ environment_variables[name=REGION].value: "us-east-1" => "eu-west-1"
+- pipelines[name=up42]: {"name":"up42"}
++ pipelines[name=up44]: {"name":"up44"}`, changes.String())
+	})
+
+	t.Run("should compare the lists without identity by index", func(t *testing.T) {
+		before := gocd.Role{Name: "admins", Attributes: gocd.RoleAttribute{Users: []string{"alice", "bob"}}}
+		after := gocd.Role{Name: "admins", Attributes: gocd.RoleAttribute{Users: []string{"alice", "carol", "dave"}}, ETAG: "etag-2"}
+
+		changes, err := diff.Compare(before, after)
+		require.NoError(t, err)
+
+		assert.Equal(t, `~ attributes.users[1]: "bob" => "carol"
++ attributes.users[2]: "dave"`, changes.String())
+	})
+
+	t.Run("should report the nested changes of pipeline config and ignore the fields requested", func(t *testing.T) {
+		before := gocd.PipelineConfig{
+			Name:  "up42",
+			Group: "first",
+			Stages: []gocd.PipelineStageConfig{{
+				Name: "build",
+				Jobs: []gocd.PipelineJobConfig{{Name: "test", Timeout: 10}},
+			}},
+			Config: map[string]any{"_links": map[string]any{"self": "http://ci.example.com/go/api/admin/pipelines/up42"}},
+		}
+		after := before
+		after.Group = "second"
+		after.Stages = []gocd.PipelineStageConfig{{Name: "build", Jobs: []gocd.PipelineJobConfig{{Name: "test", Timeout: 20}}}}
+		after.Config = map[string]any{"_links": map[string]any{"self": "http://ci2.example.com/go/api/admin/pipelines/up42"}}
+
+		changes, err := diff.Compare(before, after, diff.IgnoreFields("group"))
+		require.NoError(t, err)
+
+		assert.Equal(t, diff.Changes{
+			{Path: "stages[name=build].jobs[name=test].timeout", Type: diff.Modified, Old: float64(10), New: float64(20)},
+		}, changes)
+	})
+
+	t.Run("should render the changes as JSON", func(t *testing.T) {
+		changes, err := diff.Compare(gocd.CommonConfig{ID: "docker"}, gocd.CommonConfig{ID: "docker", PluginID: "cd.go.docker"})
+		require.NoError(t, err)
+
+		out, err := changes.JSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"path": "plugin_id", "type": "added", "new": "cd.go.docker"}]`, string(out))
+
+		changes, err = diff.Compare(gocd.CommonConfig{ID: "docker"}, gocd.CommonConfig{ID: "docker", ETAG: "etag-2"})
+		require.NoError(t, err)
+		assert.True(t, changes.Empty())
+
+		out, err = changes.JSON()
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(out))
+	})
+}
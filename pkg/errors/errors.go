@@ -1,7 +1,10 @@
 package errors
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 func (err NonOkError) Error() string {
@@ -45,3 +48,93 @@ func (err GoCDSDKError) Error() string {
 func (err PipelineValidationError) Error() string {
 	return err.Message
 }
+
+func (err APIValidationError) Error() string {
+	if len(err.Fields) == 0 {
+		return err.Message
+	}
+
+	fields := make([]string, 0, len(err.Fields))
+	for field := range err.Fields {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	fieldErrors := make([]string, 0, len(fields))
+	for _, field := range fields {
+		fieldErrors = append(fieldErrors, fmt.Sprintf("%s: %s", field, strings.Join(err.Fields[field], ", ")))
+	}
+
+	return fmt.Sprintf("%s [%s]", err.Message, strings.Join(fieldErrors, "; "))
+}
+
+func (err ServersError) Error() string {
+	servers := err.servers()
+
+	serverErrors := make([]string, 0, len(servers))
+	for _, server := range servers {
+		serverErrors = append(serverErrors, fmt.Sprintf("%s: %v", server, err.Errors[server]))
+	}
+
+	return fmt.Sprintf("call errored on %d of the GoCD servers [%s]", len(servers), strings.Join(serverErrors, "; "))
+}
+
+func (err UnsupportedOnServerVersion) Error() string {
+	if len(err.Since) != 0 {
+		return fmt.Sprintf("%s is not supported on GoCD server version %s, it is available since %s", err.Method, err.ServerVersion, err.Since)
+	}
+
+	return fmt.Sprintf("%s is not supported on GoCD server version %s, as it no longer serves '%s'", err.Method, err.ServerVersion, err.Endpoint)
+}
+
+func (err ModifyConflictError) Error() string {
+	return fmt.Sprintf("updating %s failed as its ETag conflicted with GoCD on every of %d attempts, it was modified in between: %v",
+		err.Object, err.Attempts, err.Err)
+}
+
+func (err PipelineWaitTimeoutError) Error() string {
+	return fmt.Sprintf("pipeline '%s' did not complete within %s", err.Pipeline, err.Timeout)
+}
+
+func (err APIError) Unwrap() error {
+	return err.Err
+}
+
+func (err MarshalError) Unwrap() error {
+	return err.Err
+}
+
+func (err GoCDError) Unwrap() error {
+	return err.Err
+}
+
+func (err ModifyConflictError) Unwrap() error {
+	return err.Err
+}
+
+func (err PipelineWaitTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+func (err ServersError) Unwrap() []error {
+	servers := err.servers()
+
+	errs := make([]error, 0, len(servers))
+	for _, server := range servers {
+		errs = append(errs, err.Errors[server])
+	}
+
+	return errs
+}
+
+func (err ServersError) servers() []string {
+	servers := make([]string, 0, len(err.Errors))
+	for server := range err.Errors {
+		servers = append(servers, server)
+	}
+
+	sort.Strings(servers)
+
+	return servers
+}
@@ -0,0 +1,94 @@
+package errors_test
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newResponse(body string) *resty.Response {
+	return (&resty.Response{RawResponse: &http.Response{}}).SetBody([]byte(body))
+}
+
+func TestIsStatusHelpers(t *testing.T) {
+	t.Run("should identify the errors based on the status code returned by GoCD", func(t *testing.T) {
+		tests := []struct {
+			code    int
+			checker func(err error) bool
+		}{
+			{code: http.StatusNotFound, checker: errors.IsNotFound},
+			{code: http.StatusConflict, checker: errors.IsConflict},
+			{code: http.StatusPreconditionFailed, checker: errors.IsPreconditionFailed},
+			{code: http.StatusUnauthorized, checker: errors.IsUnauthorized},
+			{code: http.StatusForbidden, checker: errors.IsForbidden},
+			{code: http.StatusUnprocessableEntity, checker: errors.IsUnprocessable},
+		}
+
+		for _, test := range tests {
+			err := fmt.Errorf("wrapped: %w", &errors.NonOkError{Code: test.code, Response: newResponse("")})
+			assert.True(t, test.checker(err), "status code %d", test.code)
+			assert.False(t, test.checker(&errors.NonOkError{Code: http.StatusBadGateway, Response: newResponse("")}))
+		}
+	})
+
+	t.Run("should identify NonFoundError as not found", func(t *testing.T) {
+		assert.True(t, errors.IsNotFound(&errors.NonFoundError{Code: http.StatusNotFound}))
+		assert.True(t, goErrors.Is(&errors.NonFoundError{}, errors.ErrNotFound))
+	})
+
+	t.Run("should not identify errors that are not from GoCD", func(t *testing.T) {
+		assert.False(t, errors.IsNotFound(&errors.APIError{Err: context.Canceled, Message: "get agents"}))
+		assert.False(t, errors.IsNotFound(nil))
+	})
+
+	t.Run("should be able to unwrap the underlying error of APIError", func(t *testing.T) {
+		assert.True(t, goErrors.Is(&errors.APIError{Err: context.Canceled, Message: "get agents"}, context.Canceled))
+	})
+}
+
+func TestParseAPIValidationError(t *testing.T) {
+	t.Run("should parse the field wise errors from the GoCD error response", func(t *testing.T) {
+		body := `{
+  "message": "Validations failed for pipeline 'new_pipeline'. Error(s): [Validation failed.]. Please correct and resubmit.",
+  "data": {
+    "name": "new_pipeline",
+    "errors": {"label_template": ["Invalid label template"]},
+    "materials": [
+      {"type": "git", "attributes": {"url": "", "errors": {"url": ["URL cannot be blank", "URL is invalid"]}}}
+    ]
+  }
+}`
+		err := &errors.NonOkError{Code: http.StatusUnprocessableEntity, Response: newResponse(body)}
+
+		validationErr, ok := errors.AsValidationError(err)
+		require.True(t, ok)
+
+		expected := &errors.APIValidationError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: "Validations failed for pipeline 'new_pipeline'. Error(s): [Validation failed.]. Please correct and resubmit.",
+			Fields: map[string][]string{
+				"label_template":              {"Invalid label template"},
+				"materials[0].attributes.url": {"URL cannot be blank", "URL is invalid"},
+			},
+		}
+		assert.Equal(t, expected, validationErr)
+		assert.Equal(t, "Validations failed for pipeline 'new_pipeline'. Error(s): [Validation failed.]. Please correct and resubmit. "+
+			"[label_template: Invalid label template; materials[0].attributes.url: URL cannot be blank, URL is invalid]", validationErr.Error())
+		assert.True(t, errors.IsUnprocessable(err))
+	})
+
+	t.Run("should return nil when the response does not have a message", func(t *testing.T) {
+		assert.Nil(t, errors.ParseAPIValidationError(http.StatusBadGateway, newResponse("<html>bad gateway</html>")))
+		assert.Nil(t, errors.ParseAPIValidationError(http.StatusBadGateway, nil))
+
+		_, ok := errors.AsValidationError(&errors.NonOkError{Code: http.StatusBadGateway, Response: newResponse("bad gateway")})
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,170 @@
+package errors
+
+import (
+	"encoding/json"
+	goErrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// StatusError is the sentinel error matched by errors.Is against NonOkError and NonFoundError based on the status code.
+type StatusError struct {
+	Code int
+}
+
+var (
+	// ErrNotFound matches errors from GoCD that responded with 404.
+	ErrNotFound = &StatusError{Code: http.StatusNotFound}
+	// ErrConflict matches errors from GoCD that responded with 409.
+	ErrConflict = &StatusError{Code: http.StatusConflict}
+	// ErrPreconditionFailed matches errors from GoCD that responded with 412, mostly due to stale ETag.
+	ErrPreconditionFailed = &StatusError{Code: http.StatusPreconditionFailed}
+	// ErrUnauthorized matches errors from GoCD that responded with 401.
+	ErrUnauthorized = &StatusError{Code: http.StatusUnauthorized}
+	// ErrForbidden matches errors from GoCD that responded with 403.
+	ErrForbidden = &StatusError{Code: http.StatusForbidden}
+	// ErrUnprocessable matches errors from GoCD that responded with 422, mostly due to validation failures.
+	ErrUnprocessable = &StatusError{Code: http.StatusUnprocessableEntity}
+)
+
+func (err StatusError) Error() string {
+	return fmt.Sprintf("GoCD responded with %d %s", err.Code, http.StatusText(err.Code))
+}
+
+// Is reports whether the NonOkError has the same status code as that of the target StatusError.
+func (err NonOkError) Is(target error) bool {
+	return isStatus(err.Code, target)
+}
+
+// Unwrap returns the APIValidationError parsed from the response body when GoCD responded with an error message.
+func (err NonOkError) Unwrap() error {
+	if validationErr := ParseAPIValidationError(err.Code, err.Response); validationErr != nil {
+		return validationErr
+	}
+
+	return nil
+}
+
+// Is reports whether the NonFoundError matches the target, NonFoundError always matches ErrNotFound.
+func (err NonFoundError) Is(target error) bool {
+	return isStatus(http.StatusNotFound, target)
+}
+
+// IsNotFound reports whether the error is due to GoCD responding with 404.
+func IsNotFound(err error) bool {
+	return goErrors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether the error is due to GoCD responding with 409.
+func IsConflict(err error) bool {
+	return goErrors.Is(err, ErrConflict)
+}
+
+// IsPreconditionFailed reports whether the error is due to GoCD responding with 412.
+func IsPreconditionFailed(err error) bool {
+	return goErrors.Is(err, ErrPreconditionFailed)
+}
+
+// IsUnauthorized reports whether the error is due to GoCD responding with 401.
+func IsUnauthorized(err error) bool {
+	return goErrors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether the error is due to GoCD responding with 403.
+func IsForbidden(err error) bool {
+	return goErrors.Is(err, ErrForbidden)
+}
+
+// IsUnprocessable reports whether the error is due to GoCD responding with 422.
+func IsUnprocessable(err error) bool {
+	return goErrors.Is(err, ErrUnprocessable)
+}
+
+// AsValidationError returns the APIValidationError if the error chain has one.
+func AsValidationError(err error) (*APIValidationError, bool) {
+	var validationErr *APIValidationError
+	if goErrors.As(err, &validationErr) {
+		return validationErr, true
+	}
+
+	return nil, false
+}
+
+// ParseAPIValidationError parses GoCD's JSON error body, nil is returned when the body does not carry an error message.
+// GoCD returns the object that failed validation under 'data' with the field wise errors under 'errors' at each level.
+func ParseAPIValidationError(code int, response *resty.Response) *APIValidationError {
+	if response == nil {
+		return nil
+	}
+
+	var body struct {
+		Message string      `json:"message"`
+		Data    interface{} `json:"data"`
+	}
+
+	if err := json.Unmarshal(response.Body(), &body); err != nil || len(body.Message) == 0 {
+		return nil
+	}
+
+	validationErr := &APIValidationError{
+		Code:    code,
+		Message: body.Message,
+		Fields:  make(map[string][]string),
+	}
+
+	collectFieldErrors("", body.Data, validationErr.Fields)
+
+	return validationErr
+}
+
+func collectFieldErrors(path string, data interface{}, fields map[string][]string) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if key != "errors" {
+				collectFieldErrors(joinPath(path, key), nested, fields)
+
+				continue
+			}
+
+			fieldErrors, ok := nested.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for field, messages := range fieldErrors {
+				messageList, ok := messages.([]interface{})
+				if !ok {
+					continue
+				}
+
+				for _, message := range messageList {
+					fields[joinPath(path, field)] = append(fields[joinPath(path, field)], fmt.Sprintf("%v", message))
+				}
+			}
+		}
+	case []interface{}:
+		for index, nested := range value {
+			collectFieldErrors(fmt.Sprintf("%s[%d]", path, index), nested, fields)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func isStatus(code int, target error) bool {
+	var statusErr *StatusError
+	if !goErrors.As(target, &statusErr) {
+		return false
+	}
+
+	return statusErr.Code == code
+}
@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"time"
+
 	"github.com/go-resty/resty/v2"
 )
 
@@ -38,7 +40,48 @@ type PipelineValidationError struct {
 	Message string
 }
 
+// NonFoundError is the error for the objects not found in GoCD.
+//
+// Deprecated: it is not returned by the SDK, the calls responded with 404 return NonOkError, check them using IsNotFound.
 type NonFoundError struct {
 	Code     int
 	Response *resty.Response
 }
+
+// APIValidationError holds the error message and the field wise validation errors returned by GoCD.
+// Fields are addressed by their path in the request object, for example 'materials[0].attributes.url'.
+type APIValidationError struct {
+	Code    int
+	Message string
+	Fields  map[string][]string
+}
+
+// ServersError holds the errors of a call made to several GoCD servers, keyed by the name of the server it failed on.
+type ServersError struct {
+	Errors map[string]error
+}
+
+// UnsupportedOnServerVersion is returned when the method called is not available on the version of the GoCD server,
+// detected when the version negotiation is enabled on the client.
+type UnsupportedOnServerVersion struct {
+	Method        string
+	Endpoint      string
+	ServerVersion string
+	// Since is the version of GoCD the endpoint is available from, it is empty when the endpoint is no longer served.
+	Since string
+}
+
+// ModifyConflictError is returned when the object could not be updated as it was modified in between on every attempt,
+// it wraps the error of the last attempt, which matches ErrPreconditionFailed.
+type ModifyConflictError struct {
+	Object   string
+	Attempts int
+	Err      error
+}
+
+// PipelineWaitTimeoutError is returned when the pipeline run waited for did not complete within the timeout,
+// it wraps context.DeadlineExceeded.
+type PipelineWaitTimeoutError struct {
+	Pipeline string
+	Timeout  time.Duration
+}
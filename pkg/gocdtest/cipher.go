@@ -0,0 +1,89 @@
+package gocdtest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+)
+
+const (
+	encryptedPrefix = "AES:"
+	cipherKeyLength = 8
+)
+
+// cipher encrypts the secure values with the cipher key of the fake server, the values encrypted by another server
+// could not be decrypted by it, as is the case with GoCD.
+type cipher struct {
+	key string
+}
+
+func newCipher() cipher {
+	key := make([]byte, cipherKeyLength)
+	_, _ = rand.Read(key)
+
+	return cipher{key: hex.EncodeToString(key)}
+}
+
+func (c cipher) encrypt(value string) string {
+	return encryptedPrefix + c.key + ":" + base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+func (c cipher) decryptable(encrypted string) bool {
+	return len(encrypted) == 0 || strings.HasPrefix(encrypted, encryptedPrefix+c.key+":")
+}
+
+// encryptProperties encrypts the plain text values of the secure properties.
+func (c cipher) encryptProperties(properties []gocd.PluginConfiguration) []gocd.PluginConfiguration {
+	properties = slices.Clone(properties)
+
+	for index, property := range properties {
+		if property.IsSecure && len(property.Value) != 0 {
+			properties[index].EncryptedValue, properties[index].Value = c.encrypt(property.Value), ""
+		}
+	}
+
+	return properties
+}
+
+// encryptEnvVars encrypts the plain text values of the secure environment variables.
+func (c cipher) encryptEnvVars(envVars []gocd.EnvVars) []gocd.EnvVars {
+	envVars = slices.Clone(envVars)
+
+	for index, envVar := range envVars {
+		if envVar.Secure && len(envVar.Value) != 0 {
+			envVars[index].EncryptedValue, envVars[index].Value = c.encrypt(envVar.Value), ""
+		}
+	}
+
+	return envVars
+}
+
+// validateProperties reports the property having the encrypted value that could not be decrypted by the server.
+func (c cipher) validateProperties(properties []gocd.PluginConfiguration) (string, string) {
+	for _, property := range properties {
+		if !c.decryptable(property.EncryptedValue) {
+			return "properties", invalidEncryptedValue("property with key '" + property.Key + "'")
+		}
+	}
+
+	return "", ""
+}
+
+// validateEnvVars reports the environment variable having the encrypted value that could not be decrypted by the server.
+func (c cipher) validateEnvVars(envVars []gocd.EnvVars) (string, string) {
+	for _, envVar := range envVars {
+		if !c.decryptable(envVar.EncryptedValue) {
+			return "environment_variables", invalidEncryptedValue("environment variable '" + envVar.Name + "'")
+		}
+	}
+
+	return "", ""
+}
+
+func invalidEncryptedValue(of string) string {
+	return "Encrypted value for " + of + " is invalid. This usually happens when the cipher text is modified to have an invalid value."
+}
@@ -0,0 +1,289 @@
+package gocdtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// resource serves the CRUD APIs of a kind of object held in the store, the way GoCD does.
+type resource[T any] struct {
+	store *store[T]
+	// embedded is the key under '_embedded' holding the objects when listed.
+	embedded string
+	// keyField is the JSON field that identifies the object, used while reporting validation errors.
+	keyField string
+	// filter selects the objects to be listed based on the query parameters, all objects are listed when nil.
+	filter func(req *http.Request, item T) bool
+	// prepare lets the object be adjusted before it is saved, previous is nil when the object is being created.
+	prepare func(previous *T, item T) T
+	// saved is invoked after the object is saved, to update the objects depending on it.
+	saved func(previous *T, item T)
+	// removable reports the reason the object could not be deleted, empty when it could be deleted.
+	removable func(item T) string
+	// removed is invoked after the object is deleted, to update the objects depending on it.
+	removed func(item T)
+	// patch applies the body of PATCH call to the object, the fields present in the body are merged when nil.
+	patch func(item T, body []byte) (T, error)
+	// validate reports the field and the reason the object could not be saved, empty reason when it could be saved.
+	validate func(item T) (string, string)
+}
+
+func (r *resource[T]) save(previous *T, item T) T {
+	if r.prepare != nil {
+		item = r.prepare(previous, item)
+	}
+
+	r.store.put(item)
+
+	if r.saved != nil {
+		r.saved(previous, item)
+	}
+
+	item, _ = r.store.get(r.store.key(item))
+
+	return item
+}
+
+func (r *resource[T]) list(writer http.ResponseWriter, req *http.Request) {
+	items := make([]T, 0)
+
+	for _, item := range r.store.list() {
+		if r.filter == nil || r.filter(req, item) {
+			items = append(items, item)
+		}
+	}
+
+	writeJSON(writer, req, http.StatusOK, map[string]any{"_embedded": map[string]any{r.embedded: items}}, etag(items))
+}
+
+func (r *resource[T]) get(writer http.ResponseWriter, req *http.Request) {
+	item, ok := r.store.get(req.PathValue("name"))
+	if !ok {
+		writeMessage(writer, req, http.StatusNotFound, notFoundMessage)
+
+		return
+	}
+
+	writeJSON(writer, req, http.StatusOK, item, etag(item))
+}
+
+func (r *resource[T]) create(writer http.ResponseWriter, req *http.Request) {
+	var item T
+
+	if !decode(writer, req, &item) {
+		return
+	}
+
+	name := r.store.key(item)
+	if len(name) == 0 {
+		r.writeValidationError(writer, req, item,
+			fmt.Sprintf("Validations failed for %s ''. Error(s): [Validation failed.]. Please correct and resubmit.", r.store.kind),
+			r.keyField, fmt.Sprintf("%s cannot be blank", r.keyField))
+
+		return
+	}
+
+	if _, ok := r.store.get(name); ok {
+		r.writeValidationError(writer, req, item,
+			fmt.Sprintf("Failed to add %s '%s'. Another %s with the same name already exists.", r.store.kind, name, r.store.kind),
+			r.keyField, fmt.Sprintf("%s '%s' already exists", r.store.kind, name))
+
+		return
+	}
+
+	if !r.valid(writer, req, item) {
+		return
+	}
+
+	item = r.save(nil, item)
+
+	writeJSON(writer, req, http.StatusOK, item, etag(item))
+}
+
+func (r *resource[T]) update(writer http.ResponseWriter, req *http.Request) {
+	name := req.PathValue("name")
+
+	previous, ok := r.store.get(name)
+	if !ok {
+		writeMessage(writer, req, http.StatusNotFound, notFoundMessage)
+
+		return
+	}
+
+	if req.Header.Get("If-Match") != etag(previous) {
+		writeMessage(writer, req, http.StatusPreconditionFailed, fmt.Sprintf("Someone has modified the configuration for %s '%s'. "+
+			"Please update your copy of the config with the changes and try again.", r.store.kind, name))
+
+		return
+	}
+
+	var item T
+
+	if !decode(writer, req, &item) {
+		return
+	}
+
+	r.replace(writer, req, previous, item)
+}
+
+func (r *resource[T]) modify(writer http.ResponseWriter, req *http.Request) {
+	name := req.PathValue("name")
+
+	previous, ok := r.store.get(name)
+	if !ok {
+		writeMessage(writer, req, http.StatusNotFound, notFoundMessage)
+
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeMessage(writer, req, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	patch := r.patch
+	if patch == nil {
+		patch = merge[T]
+	}
+
+	item, err := patch(previous, body)
+	if err != nil {
+		writeMessage(writer, req, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	r.replace(writer, req, previous, item)
+}
+
+func (r *resource[T]) replace(writer http.ResponseWriter, req *http.Request, previous, item T) {
+	if name := req.PathValue("name"); r.store.key(item) != name {
+		r.writeValidationError(writer, req, item, fmt.Sprintf("Renaming of %s '%s' is not supported by this API.", r.store.kind, name),
+			r.keyField, fmt.Sprintf("%s cannot be changed", r.keyField))
+
+		return
+	}
+
+	if !r.valid(writer, req, item) {
+		return
+	}
+
+	item = r.save(&previous, item)
+
+	writeJSON(writer, req, http.StatusOK, item, etag(item))
+}
+
+func (r *resource[T]) remove(writer http.ResponseWriter, req *http.Request) {
+	name := req.PathValue("name")
+
+	item, ok := r.store.get(name)
+	if !ok {
+		writeMessage(writer, req, http.StatusNotFound, notFoundMessage)
+
+		return
+	}
+
+	if r.removable != nil {
+		if reason := r.removable(item); len(reason) != 0 {
+			writeMessage(writer, req, http.StatusUnprocessableEntity, reason)
+
+			return
+		}
+	}
+
+	r.store.remove(name)
+
+	if r.removed != nil {
+		r.removed(item)
+	}
+
+	writeMessage(writer, req, http.StatusOK, fmt.Sprintf("The %s '%s' was deleted successfully.", r.store.kind, name))
+}
+
+func (r *resource[T]) valid(writer http.ResponseWriter, req *http.Request, item T) bool {
+	if r.validate == nil {
+		return true
+	}
+
+	field, reason := r.validate(item)
+	if len(reason) == 0 {
+		return true
+	}
+
+	r.writeValidationError(writer, req, item,
+		fmt.Sprintf("Validations failed for %s '%s'. Error(s): [Validation failed.]. Please correct and resubmit.", r.store.kind, r.store.key(item)),
+		field, reason)
+
+	return false
+}
+
+// writeValidationError responds with 422 along with the object and the field wise errors under 'data', as GoCD does.
+func (r *resource[T]) writeValidationError(writer http.ResponseWriter, req *http.Request, item T, message, field, fieldError string) {
+	data := make(map[string]any)
+
+	content, _ := json.Marshal(item)
+	_ = json.Unmarshal(content, &data)
+
+	data["errors"] = map[string][]string{field: {fieldError}}
+
+	writeJSON(writer, req, http.StatusUnprocessableEntity, map[string]any{"message": message, "data": data}, "")
+}
+
+// merge applies the fields present in the body over a copy of the object.
+func merge[T any](item T, body []byte) (T, error) {
+	var merged T
+
+	content, err := json.Marshal(item)
+	if err != nil {
+		return merged, err
+	}
+
+	if err = json.Unmarshal(content, &merged); err != nil {
+		return merged, err
+	}
+
+	err = json.Unmarshal(body, &merged)
+
+	return merged, err
+}
+
+func decode(writer http.ResponseWriter, req *http.Request, item any) bool {
+	if err := json.NewDecoder(req.Body).Decode(item); err != nil {
+		writeMessage(writer, req, http.StatusBadRequest, "Error parsing the request body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+func writeMessage(writer http.ResponseWriter, req *http.Request, code int, message string) {
+	writeJSON(writer, req, code, map[string]string{"message": message}, "")
+}
+
+func writeJSON(writer http.ResponseWriter, req *http.Request, code int, body any, eTag string) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		content = []byte(fmt.Sprintf(`{"message": %q}`, err.Error()))
+	}
+
+	contentType := req.Header.Get("Accept")
+	if !strings.HasPrefix(contentType, "application/vnd.go.cd") {
+		contentType = "application/json"
+	}
+
+	writer.Header().Set("Content-Type", contentType+"; charset=utf-8")
+
+	if len(eTag) != 0 {
+		writer.Header().Set("ETag", eTag)
+	}
+
+	writer.WriteHeader(code)
+	_, _ = writer.Write(content)
+}
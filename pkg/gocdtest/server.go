@@ -0,0 +1,434 @@
+// Package gocdtest provides a stateful, in-memory fake GoCD server to test the code built using gocd-sdk-go end-to-end.
+//
+// The server serves agents, pipeline groups, pipelines, environments, config repos, roles, users, auth configs,
+// cluster profiles, elastic agent profiles and secret configs, honouring the
+// Accept versions, ETag/If-Match semantics and status codes that the client expects from GoCD.
+// Calls to any other API are responded with 404.
+//
+// Secure properties and environment variables are encrypted with a cipher key of the server's own, so the encrypted
+// values of one server are rejected by another, as GoCD does.
+package gocdtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+)
+
+const (
+	notFoundMessage         = "Either the resource you requested was not found, or you are not authorized to perform this action."
+	incorrectVersionMessage = "The url you are trying to reach appears to be incorrect."
+)
+
+// Server is the fake GoCD server, seed it with the objects using the Add methods before exercising the code under test.
+type Server struct {
+	*httptest.Server
+	mutex           sync.Mutex
+	cipher          cipher
+	agents          *resource[gocd.Agent]
+	pipelineGroups  *resource[gocd.PipelineGroup]
+	pipelines       *resource[gocd.PipelineConfig]
+	environments    *resource[gocd.Environment]
+	configRepos     *resource[gocd.ConfigRepo]
+	roles           *resource[gocd.Role]
+	users           *resource[gocd.User]
+	authConfigs     *resource[gocd.CommonConfig]
+	clusterProfiles *resource[gocd.CommonConfig]
+	elasticProfiles *resource[gocd.CommonConfig]
+	secretConfigs   *resource[gocd.CommonConfig]
+}
+
+// NewServer starts and returns a new fake GoCD server with no objects, the caller should call Close when finished.
+func NewServer() *Server {
+	server := &Server{
+		cipher: newCipher(),
+		agents: &resource[gocd.Agent]{
+			store:    newStore("agent", func(agent gocd.Agent) string { return agent.ID }),
+			embedded: "agents",
+			keyField: "uuid",
+		},
+		pipelineGroups: &resource[gocd.PipelineGroup]{
+			store:    newStore("pipeline group", func(group gocd.PipelineGroup) string { return group.Name }),
+			embedded: "groups",
+			keyField: "name",
+		},
+		pipelines: &resource[gocd.PipelineConfig]{
+			store:    newStore("pipeline", func(pipeline gocd.PipelineConfig) string { return pipeline.Name }),
+			keyField: "name",
+		},
+		environments: &resource[gocd.Environment]{
+			store:    newStore("environment", func(environment gocd.Environment) string { return environment.Name }),
+			embedded: "environments",
+			keyField: "name",
+			patch:    patchEnvironment,
+		},
+		configRepos: &resource[gocd.ConfigRepo]{
+			store:    newStore("config repo", func(repo gocd.ConfigRepo) string { return repo.ID }),
+			embedded: "config_repos",
+			keyField: "id",
+		},
+		roles: &resource[gocd.Role]{
+			store:    newStore("role", func(role gocd.Role) string { return role.Name }),
+			embedded: "roles",
+			keyField: "name",
+			filter: func(req *http.Request, role gocd.Role) bool {
+				roleType := req.URL.Query().Get("type")
+
+				return len(roleType) == 0 || strings.ToLower(role.Type) == roleType
+			},
+		},
+		users: &resource[gocd.User]{
+			store:    newStore("user", func(user gocd.User) string { return user.LoginName }),
+			embedded: "users",
+			keyField: "login_name",
+		},
+		authConfigs: &resource[gocd.CommonConfig]{
+			store:    newStore("security auth config", configID),
+			embedded: "auth_configs",
+			keyField: "id",
+		},
+		clusterProfiles: &resource[gocd.CommonConfig]{
+			store:    newStore("cluster profile", configID),
+			embedded: "profiles",
+			keyField: "id",
+		},
+		elasticProfiles: &resource[gocd.CommonConfig]{
+			store:    newStore("elastic agent profile", configID),
+			embedded: "profiles",
+			keyField: "id",
+		},
+		secretConfigs: &resource[gocd.CommonConfig]{
+			store:    newStore("secret config", configID),
+			embedded: "secret_configs",
+			keyField: "id",
+		},
+	}
+
+	server.pipelineGroups.prepare = server.preparePipelineGroup
+	server.pipelineGroups.removable = server.pipelineGroupRemovable
+	server.pipelines.saved = server.pipelineSaved
+	server.pipelines.removed = server.pipelineRemoved
+	server.clusterProfiles.removable = server.clusterProfileRemovable
+	server.elasticProfiles.validate = server.validateElasticProfile
+	server.environments.prepare = server.prepareEnvironment
+	server.environments.validate = server.validateEnvironment
+
+	for _, configs := range []*resource[gocd.CommonConfig]{
+		server.authConfigs, server.clusterProfiles, server.elasticProfiles, server.secretConfigs,
+	} {
+		configs.prepare = server.prepareCommonConfig
+	}
+
+	server.authConfigs.validate = server.validateCommonConfig
+	server.clusterProfiles.validate = server.validateCommonConfig
+	server.secretConfigs.validate = server.validateCommonConfig
+
+	server.Server = httptest.NewServer(server.routes())
+
+	return server
+}
+
+// GoCdClient returns the client of gocd-sdk-go configured to talk to the fake server.
+func (server *Server) GoCdClient() gocd.GoCd {
+	return gocd.NewClient(server.URL, gocd.Auth{}, "info", nil)
+}
+
+// AddAgents adds the agents to the server, agents are identified by their ID.
+func (server *Server) AddAgents(agents ...gocd.Agent) {
+	addAll(server, server.agents, agents)
+}
+
+// AddPipelineGroups adds the pipeline groups to the server, pipelines of the group should be added using AddPipelines.
+func (server *Server) AddPipelineGroups(groups ...gocd.PipelineGroup) {
+	addAll(server, server.pipelineGroups, groups)
+}
+
+// AddPipelines adds the pipelines to the server, the group of the pipeline is created when it does not exist.
+func (server *Server) AddPipelines(pipelines ...gocd.PipelineConfig) {
+	addAll(server, server.pipelines, pipelines)
+}
+
+// AddEnvironments adds the environments to the server.
+func (server *Server) AddEnvironments(environments ...gocd.Environment) {
+	addAll(server, server.environments, environments)
+}
+
+// AddConfigRepos adds the config repos to the server, config repos are identified by their ID.
+func (server *Server) AddConfigRepos(repos ...gocd.ConfigRepo) {
+	addAll(server, server.configRepos, repos)
+}
+
+// AddRoles adds the roles to the server.
+func (server *Server) AddRoles(roles ...gocd.Role) {
+	addAll(server, server.roles, roles)
+}
+
+// AddUsers adds the users to the server, users are identified by their login name.
+func (server *Server) AddUsers(users ...gocd.User) {
+	addAll(server, server.users, users)
+}
+
+// AddAuthConfigs adds the security auth configs to the server, auth configs are identified by their ID.
+func (server *Server) AddAuthConfigs(configs ...gocd.CommonConfig) {
+	addAll(server, server.authConfigs, configs)
+}
+
+// AddClusterProfiles adds the cluster profiles to the server, cluster profiles are identified by their ID.
+func (server *Server) AddClusterProfiles(profiles ...gocd.CommonConfig) {
+	addAll(server, server.clusterProfiles, profiles)
+}
+
+// AddElasticAgentProfiles adds the elastic agent profiles to the server, unlike the API the cluster profile
+// referred by the elastic agent profile is not validated.
+func (server *Server) AddElasticAgentProfiles(profiles ...gocd.CommonConfig) {
+	addAll(server, server.elasticProfiles, profiles)
+}
+
+// AddSecretConfigs adds the secret configs to the server, secret configs are identified by their ID.
+func (server *Server) AddSecretConfigs(configs ...gocd.CommonConfig) {
+	addAll(server, server.secretConfigs, configs)
+}
+
+func configID(config gocd.CommonConfig) string {
+	return config.ID
+}
+
+func addAll[T any](server *Server, res *resource[T], items []T) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for _, item := range items {
+		if previous, ok := res.store.get(res.store.key(item)); ok {
+			res.save(&previous, item)
+
+			continue
+		}
+
+		res.save(nil, item)
+	}
+}
+
+func (server *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	handle := func(method, path, accept string, handler http.HandlerFunc) {
+		mux.HandleFunc(method+" "+path, func(writer http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Accept") != accept {
+				writeMessage(writer, req, http.StatusNotFound, incorrectVersionMessage)
+
+				return
+			}
+
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+
+			handler(writer, req)
+		})
+	}
+
+	byName := func(endpoint string) string {
+		return filepath.Join(endpoint, "{name}")
+	}
+
+	handle(http.MethodGet, gocd.AgentsEndpoint, gocd.HeaderVersionSeven, server.agents.list)
+	handle(http.MethodGet, byName(gocd.AgentsEndpoint), gocd.HeaderVersionSeven, server.agents.get)
+	handle(http.MethodPatch, byName(gocd.AgentsEndpoint), gocd.HeaderVersionSeven, server.agents.modify)
+	handle(http.MethodDelete, byName(gocd.AgentsEndpoint), gocd.HeaderVersionSeven, server.agents.remove)
+
+	handle(http.MethodGet, gocd.PipelineGroupEndpoint, gocd.HeaderVersionOne, server.pipelineGroups.list)
+	handle(http.MethodPost, gocd.PipelineGroupEndpoint, gocd.HeaderVersionOne, server.pipelineGroups.create)
+	handle(http.MethodGet, byName(gocd.PipelineGroupEndpoint), gocd.HeaderVersionOne, server.pipelineGroups.get)
+	handle(http.MethodPut, byName(gocd.PipelineGroupEndpoint), gocd.HeaderVersionOne, server.pipelineGroups.update)
+	handle(http.MethodDelete, byName(gocd.PipelineGroupEndpoint), gocd.HeaderVersionOne, server.pipelineGroups.remove)
+
+	handle(http.MethodPost, gocd.PipelineConfigEndpoint, gocd.HeaderVersionEleven, server.pipelines.create)
+	handle(http.MethodGet, byName(gocd.PipelineConfigEndpoint), gocd.HeaderVersionEleven, server.pipelines.get)
+	handle(http.MethodPut, byName(gocd.PipelineConfigEndpoint), gocd.HeaderVersionEleven, server.pipelines.update)
+	handle(http.MethodDelete, byName(gocd.PipelineConfigEndpoint), gocd.HeaderVersionEleven, server.pipelines.remove)
+
+	handle(http.MethodGet, gocd.EnvironmentEndpoint, gocd.HeaderVersionThree, server.environments.list)
+	handle(http.MethodPost, gocd.EnvironmentEndpoint, gocd.HeaderVersionThree, server.environments.create)
+	handle(http.MethodGet, byName(gocd.EnvironmentEndpoint), gocd.HeaderVersionThree, server.environments.get)
+	handle(http.MethodPut, byName(gocd.EnvironmentEndpoint), gocd.HeaderVersionThree, server.environments.update)
+	handle(http.MethodPatch, byName(gocd.EnvironmentEndpoint), gocd.HeaderVersionThree, server.environments.modify)
+	handle(http.MethodDelete, byName(gocd.EnvironmentEndpoint), gocd.HeaderVersionThree, server.environments.remove)
+
+	handle(http.MethodGet, gocd.ConfigReposEndpoint, gocd.HeaderVersionFour, server.configRepos.list)
+	handle(http.MethodPost, gocd.ConfigReposEndpoint, gocd.HeaderVersionFour, server.configRepos.create)
+	handle(http.MethodGet, byName(gocd.ConfigReposEndpoint), gocd.HeaderVersionFour, server.configRepos.get)
+	handle(http.MethodPut, byName(gocd.ConfigReposEndpoint), gocd.HeaderVersionFour, server.configRepos.update)
+	handle(http.MethodDelete, byName(gocd.ConfigReposEndpoint), gocd.HeaderVersionFour, server.configRepos.remove)
+
+	handle(http.MethodGet, gocd.RolesEndpoint, gocd.HeaderVersionThree, server.roles.list)
+	handle(http.MethodPost, gocd.RolesEndpoint, gocd.HeaderVersionThree, server.roles.create)
+	handle(http.MethodGet, byName(gocd.RolesEndpoint), gocd.HeaderVersionThree, server.roles.get)
+	handle(http.MethodPut, byName(gocd.RolesEndpoint), gocd.HeaderVersionThree, server.roles.update)
+	handle(http.MethodDelete, byName(gocd.RolesEndpoint), gocd.HeaderVersionThree, server.roles.remove)
+
+	handle(http.MethodGet, gocd.UsersEndpoint, gocd.HeaderVersionThree, server.users.list)
+	handle(http.MethodPost, gocd.UsersEndpoint, gocd.HeaderVersionThree, server.users.create)
+	handle(http.MethodGet, byName(gocd.UsersEndpoint), gocd.HeaderVersionThree, server.users.get)
+	handle(http.MethodPatch, byName(gocd.UsersEndpoint), gocd.HeaderVersionThree, server.users.modify)
+	handle(http.MethodDelete, byName(gocd.UsersEndpoint), gocd.HeaderVersionThree, server.users.remove)
+
+	handle(http.MethodGet, gocd.AuthConfigEndpoint, gocd.HeaderVersionTwo, server.authConfigs.list)
+	handle(http.MethodPost, gocd.AuthConfigEndpoint, gocd.HeaderVersionTwo, server.authConfigs.create)
+	handle(http.MethodGet, byName(gocd.AuthConfigEndpoint), gocd.HeaderVersionTwo, server.authConfigs.get)
+	handle(http.MethodPut, byName(gocd.AuthConfigEndpoint), gocd.HeaderVersionTwo, server.authConfigs.update)
+	handle(http.MethodDelete, byName(gocd.AuthConfigEndpoint), gocd.HeaderVersionTwo, server.authConfigs.remove)
+
+	handle(http.MethodGet, gocd.ClusterProfileEndpoint, gocd.HeaderVersionOne, server.clusterProfiles.list)
+	handle(http.MethodPost, gocd.ClusterProfileEndpoint, gocd.HeaderVersionOne, server.clusterProfiles.create)
+	handle(http.MethodGet, byName(gocd.ClusterProfileEndpoint), gocd.HeaderVersionOne, server.clusterProfiles.get)
+	handle(http.MethodPut, byName(gocd.ClusterProfileEndpoint), gocd.HeaderVersionOne, server.clusterProfiles.update)
+	handle(http.MethodDelete, byName(gocd.ClusterProfileEndpoint), gocd.HeaderVersionOne, server.clusterProfiles.remove)
+
+	handle(http.MethodGet, gocd.AgentProfileEndpoint, gocd.HeaderVersionTwo, server.elasticProfiles.list)
+	handle(http.MethodPost, gocd.AgentProfileEndpoint, gocd.HeaderVersionTwo, server.elasticProfiles.create)
+	handle(http.MethodGet, byName(gocd.AgentProfileEndpoint), gocd.HeaderVersionTwo, server.elasticProfiles.get)
+	handle(http.MethodPut, byName(gocd.AgentProfileEndpoint), gocd.HeaderVersionTwo, server.elasticProfiles.update)
+	handle(http.MethodDelete, byName(gocd.AgentProfileEndpoint), gocd.HeaderVersionTwo, server.elasticProfiles.remove)
+
+	handle(http.MethodGet, gocd.SecretsConfigEndpoint, gocd.HeaderVersionThree, server.secretConfigs.list)
+	handle(http.MethodPost, gocd.SecretsConfigEndpoint, gocd.HeaderVersionThree, server.secretConfigs.create)
+	handle(http.MethodGet, byName(gocd.SecretsConfigEndpoint), gocd.HeaderVersionThree, server.secretConfigs.get)
+	handle(http.MethodPut, byName(gocd.SecretsConfigEndpoint), gocd.HeaderVersionThree, server.secretConfigs.update)
+	handle(http.MethodDelete, byName(gocd.SecretsConfigEndpoint), gocd.HeaderVersionThree, server.secretConfigs.remove)
+
+	return mux
+}
+
+// preparePipelineGroup retains the pipelines of the group, as pipelines are added to the group only by creating them.
+func (server *Server) preparePipelineGroup(previous *gocd.PipelineGroup, group gocd.PipelineGroup) gocd.PipelineGroup {
+	group.Pipelines = nil
+	if previous != nil {
+		group.Pipelines = previous.Pipelines
+	}
+
+	group.PipelineCount = len(group.Pipelines)
+
+	return group
+}
+
+func (server *Server) pipelineGroupRemovable(group gocd.PipelineGroup) string {
+	if len(group.Pipelines) == 0 {
+		return ""
+	}
+
+	return "Failed to delete group " + group.Name + " because it was non-empty."
+}
+
+// pipelineSaved moves the pipeline to its group, creating the group when it does not exist as GoCD does.
+func (server *Server) pipelineSaved(previous *gocd.PipelineConfig, pipeline gocd.PipelineConfig) {
+	if previous != nil {
+		if previous.Group == pipeline.Group {
+			return
+		}
+
+		server.pipelineRemoved(*previous)
+	}
+
+	group, ok := server.pipelineGroups.store.get(pipeline.Group)
+	if !ok {
+		group = gocd.PipelineGroup{Name: pipeline.Group}
+	}
+
+	group.Pipelines = append(slices.Clone(group.Pipelines), gocd.Pipeline{Name: pipeline.Name})
+	group.PipelineCount = len(group.Pipelines)
+
+	server.pipelineGroups.store.put(group)
+}
+
+func (server *Server) pipelineRemoved(pipeline gocd.PipelineConfig) {
+	group, ok := server.pipelineGroups.store.get(pipeline.Group)
+	if !ok {
+		return
+	}
+
+	group.Pipelines = slices.DeleteFunc(slices.Clone(group.Pipelines), func(groupPipeline gocd.Pipeline) bool {
+		return groupPipeline.Name == pipeline.Name
+	})
+	group.PipelineCount = len(group.Pipelines)
+
+	server.pipelineGroups.store.put(group)
+}
+
+// clusterProfileRemovable does not allow deleting the cluster profile referred by the elastic agent profiles, as GoCD does.
+func (server *Server) clusterProfileRemovable(profile gocd.CommonConfig) string {
+	for _, elasticProfile := range server.elasticProfiles.store.list() {
+		if elasticProfile.ClusterProfileID == profile.ID {
+			return "Cannot delete cluster profile '" + profile.ID + "' as it is referenced from elastic agent profile(s) ['" +
+				elasticProfile.ID + "']"
+		}
+	}
+
+	return ""
+}
+
+func (server *Server) validateElasticProfile(profile gocd.CommonConfig) (string, string) {
+	if field, reason := server.validateCommonConfig(profile); len(reason) != 0 {
+		return field, reason
+	}
+
+	if _, ok := server.clusterProfiles.store.get(profile.ClusterProfileID); ok {
+		return "", ""
+	}
+
+	return "cluster_profile_id", "No Cluster Profile exists with the specified cluster_profile_id '" + profile.ClusterProfileID + "'."
+}
+
+// prepareCommonConfig encrypts the secure properties of the config.
+func (server *Server) prepareCommonConfig(_ *gocd.CommonConfig, config gocd.CommonConfig) gocd.CommonConfig {
+	config.Properties = server.cipher.encryptProperties(config.Properties)
+
+	return config
+}
+
+func (server *Server) validateCommonConfig(config gocd.CommonConfig) (string, string) {
+	return server.cipher.validateProperties(config.Properties)
+}
+
+// prepareEnvironment encrypts the secure environment variables of the environment.
+func (server *Server) prepareEnvironment(_ *gocd.Environment, environment gocd.Environment) gocd.Environment {
+	environment.EnvVars = server.cipher.encryptEnvVars(environment.EnvVars)
+
+	return environment
+}
+
+func (server *Server) validateEnvironment(environment gocd.Environment) (string, string) {
+	return server.cipher.validateEnvVars(environment.EnvVars)
+}
+
+// patchEnvironment applies the pipelines and environment variables to be added or removed to the environment.
+func patchEnvironment(environment gocd.Environment, body []byte) (gocd.Environment, error) {
+	var envPatch gocd.PatchEnvironment
+
+	if err := json.Unmarshal(body, &envPatch); err != nil {
+		return environment, err
+	}
+
+	pipelines := slices.DeleteFunc(slices.Clone(environment.Pipelines), func(pipeline gocd.Pipeline) bool {
+		return slices.Contains(envPatch.Pipelines.Remove, pipeline.Name)
+	})
+	for _, pipeline := range envPatch.Pipelines.Add {
+		pipelines = append(pipelines, gocd.Pipeline{Name: pipeline})
+	}
+
+	envVars := slices.DeleteFunc(slices.Clone(environment.EnvVars), func(envVar gocd.EnvVars) bool {
+		return slices.Contains(envPatch.EnvVars.Remove, envVar.Name)
+	})
+	for _, envVar := range envPatch.EnvVars.Add {
+		envVars = append(envVars, gocd.EnvVars{Name: envVar.Name, Value: envVar.Value})
+	}
+
+	environment.Pipelines = pipelines
+	environment.EnvVars = envVars
+
+	return environment, nil
+}
@@ -0,0 +1,274 @@
+package gocdtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/gocdtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Agents(t *testing.T) {
+	server := gocdtest.NewServer()
+	defer server.Close()
+
+	server.AddAgents(
+		gocd.Agent{ID: "agent-1", Name: "agent-1.example.com", ConfigState: "Enabled"},
+		gocd.Agent{ID: "agent-2", Name: "agent-2.example.com", ConfigState: "Pending"},
+	)
+
+	client := server.GoCdClient()
+
+	t.Run("should list and fetch the agents added to the server", func(t *testing.T) {
+		agents, err := client.GetAgents()
+		require.NoError(t, err)
+		assert.Len(t, agents, 2)
+		assert.Equal(t, "agent-1", agents[0].ID)
+
+		agent, err := client.GetAgent("agent-2")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-2.example.com", agent.Name)
+	})
+
+	t.Run("should update only the fields sent while patching the agent", func(t *testing.T) {
+		err := client.UpdateAgent(gocd.Agent{ID: "agent-2", ConfigState: "Enabled", Resources: []string{"linux"}})
+		require.NoError(t, err)
+
+		agent, err := client.GetAgent("agent-2")
+		require.NoError(t, err)
+		assert.Equal(t, gocd.Agent{ID: "agent-2", Name: "agent-2.example.com", ConfigState: "Enabled", Resources: []string{"linux"}}, agent)
+	})
+
+	t.Run("should respond with not found once the agent is deleted", func(t *testing.T) {
+		_, err := client.DeleteAgent("agent-1")
+		require.NoError(t, err)
+
+		_, err = client.GetAgent("agent-1")
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func TestServer_PipelineGroupsAndPipelines(t *testing.T) {
+	server := gocdtest.NewServer()
+	defer server.Close()
+
+	client := server.GoCdClient()
+
+	t.Run("should honour ETag while updating the pipeline group", func(t *testing.T) {
+		require.NoError(t, client.CreatePipelineGroup(gocd.PipelineGroup{Name: "first"}))
+
+		group, err := client.GetPipelineGroup("first")
+		require.NoError(t, err)
+		require.NotEmpty(t, group.ETAG)
+
+		group.Authorization.View.Users = []string{"alice"}
+
+		updated, err := client.UpdatePipelineGroup(group)
+		require.NoError(t, err)
+		assert.NotEqual(t, group.ETAG, updated.ETAG)
+
+		_, err = client.UpdatePipelineGroup(group)
+		assert.True(t, errors.IsPreconditionFailed(err))
+	})
+
+	t.Run("should add the pipeline to its group and not allow deleting non-empty group", func(t *testing.T) {
+		pipeline, err := client.CreatePipeline(gocd.PipelineConfig{Name: "up42", Group: "first"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, pipeline.ETAG)
+
+		_, err = client.CreatePipeline(gocd.PipelineConfig{Name: "up43", Group: "second"})
+		require.NoError(t, err)
+
+		groups, err := client.GetPipelineGroups()
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, []gocd.Pipeline{{Name: "up42"}}, groups[0].Pipelines)
+		assert.Equal(t, "second", groups[1].Name)
+
+		err = client.DeletePipelineGroup("first")
+		assert.True(t, errors.IsUnprocessable(err))
+
+		require.NoError(t, client.DeletePipeline("up42"))
+		require.NoError(t, client.DeletePipelineGroup("first"))
+	})
+
+	t.Run("should report validation error while creating pipeline that already exists", func(t *testing.T) {
+		_, err := client.CreatePipeline(gocd.PipelineConfig{Name: "up43", Group: "second"})
+
+		validationErr, ok := errors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, validationErr.Code)
+		assert.Equal(t, map[string][]string{"name": {"pipeline 'up43' already exists"}}, validationErr.Fields)
+	})
+}
+
+func TestServer_Environments(t *testing.T) {
+	server := gocdtest.NewServer()
+	defer server.Close()
+
+	server.AddEnvironments(gocd.Environment{Name: "staging", Pipelines: []gocd.Pipeline{{Name: "up42"}}})
+
+	client := server.GoCdClient()
+
+	t.Run("should patch the pipelines of the environment", func(t *testing.T) {
+		envPatch := gocd.PatchEnvironment{Name: "staging"}
+		envPatch.Pipelines.Add = []string{"up43"}
+		envPatch.Pipelines.Remove = []string{"up42"}
+
+		environment, err := client.PatchEnvironment(envPatch)
+		require.NoError(t, err)
+		assert.Equal(t, []gocd.Pipeline{{Name: "up43"}}, environment.Pipelines)
+	})
+
+	t.Run("should respond with not found while updating the environment that does not exist", func(t *testing.T) {
+		environment, err := client.GetEnvironment("staging")
+		require.NoError(t, err)
+
+		environment.Name = "production"
+
+		_, err = client.UpdateEnvironment(environment)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func TestServer_ConfigReposRolesAndUsers(t *testing.T) {
+	server := gocdtest.NewServer()
+	defer server.Close()
+
+	server.AddRoles(
+		gocd.Role{Name: "admins", Type: "gocd"},
+		gocd.Role{Name: "ldap-admins", Type: "plugin"},
+	)
+	server.AddUsers(gocd.User{Name: "alice", LoginName: "alice", Enabled: true})
+
+	client := server.GoCdClient()
+
+	t.Run("should create and update the config repo with the ETag returned", func(t *testing.T) {
+		require.NoError(t, client.CreateConfigRepo(gocd.ConfigRepo{ID: "repo1", PluginID: "yaml.config.plugin"}))
+
+		repo, err := client.GetConfigRepo("repo1")
+		require.NoError(t, err)
+
+		repo.PluginID = "json.config.plugin"
+
+		eTag, err := client.UpdateConfigRepo(repo)
+		require.NoError(t, err)
+		assert.NotEqual(t, repo.ETAG, eTag)
+	})
+
+	t.Run("should filter the roles by type", func(t *testing.T) {
+		roles, err := client.GetRolesByType("plugin")
+		require.NoError(t, err)
+		require.Len(t, roles.Role, 1)
+		assert.Equal(t, "ldap-admins", roles.Role[0].Name)
+	})
+
+	t.Run("should manage users", func(t *testing.T) {
+		_, err := client.CreateUser(gocd.User{Name: "bob", LoginName: "bob"})
+		require.NoError(t, err)
+
+		user, err := client.UpdateUser(gocd.User{Name: "alice", EmailID: "alice@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, gocd.User{Name: "alice", LoginName: "alice", Enabled: true, EmailID: "alice@example.com"}, user)
+
+		require.NoError(t, client.DeleteUser("bob"))
+
+		users, err := client.GetUsers()
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+	})
+
+	t.Run("should respond with not found for the unsupported API version", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+gocd.UsersEndpoint, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", gocd.HeaderVersionOne)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestServer_ProfilesAndSecurityConfigs(t *testing.T) {
+	server := gocdtest.NewServer()
+	defer server.Close()
+
+	server.AddAuthConfigs(gocd.CommonConfig{ID: "file-auth", PluginID: "cd.go.authentication.passwordfile"})
+	server.AddSecretConfigs(gocd.CommonConfig{ID: "demo", PluginID: "cd.go.secrets.file-based-plugin"})
+
+	client := server.GoCdClient()
+
+	t.Run("should not create the elastic agent profile referring to the cluster profile that does not exist", func(t *testing.T) {
+		_, err := client.CreateElasticAgentProfile(gocd.CommonConfig{ID: "ubuntu", ClusterProfileID: "docker"})
+
+		validationErr, ok := errors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, map[string][]string{
+			"cluster_profile_id": {"No Cluster Profile exists with the specified cluster_profile_id 'docker'."},
+		}, validationErr.Fields)
+	})
+
+	t.Run("should not delete the cluster profile referred by the elastic agent profile", func(t *testing.T) {
+		_, err := client.CreateClusterProfile(gocd.CommonConfig{ID: "docker", PluginID: "cd.go.contrib.elastic-agents.docker"})
+		require.NoError(t, err)
+
+		_, err = client.CreateElasticAgentProfile(gocd.CommonConfig{ID: "ubuntu", ClusterProfileID: "docker"})
+		require.NoError(t, err)
+
+		profiles, err := client.GetElasticAgentProfiles()
+		require.NoError(t, err)
+		assert.Equal(t, []gocd.CommonConfig{{ID: "ubuntu", ClusterProfileID: "docker"}}, profiles.CommonConfigs)
+
+		err = client.DeleteClusterProfile("docker")
+		assert.True(t, errors.IsUnprocessable(err))
+
+		require.NoError(t, client.DeleteElasticAgentProfile("ubuntu"))
+		require.NoError(t, client.DeleteClusterProfile("docker"))
+	})
+
+	t.Run("should serve the auth configs and secret configs", func(t *testing.T) {
+		authConfigs, err := client.GetAuthConfigs()
+		require.NoError(t, err)
+		assert.Equal(t, []gocd.CommonConfig{{ID: "file-auth", PluginID: "cd.go.authentication.passwordfile"}}, authConfigs)
+
+		secretConfig, err := client.GetSecretConfig("demo")
+		require.NoError(t, err)
+
+		secretConfig.Description = "secrets from file"
+
+		updated, err := client.UpdateSecretConfig(secretConfig)
+		require.NoError(t, err)
+		assert.Equal(t, "secrets from file", updated.Description)
+	})
+
+	t.Run("should encrypt the secure properties and reject the values encrypted by another server", func(t *testing.T) {
+		created, err := client.CreateAuthConfig(gocd.CommonConfig{
+			ID:         "ldap",
+			PluginID:   "cd.go.authentication.ldap",
+			Properties: []gocd.PluginConfiguration{{Key: "Password", Value: "secret", IsSecure: true}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, created.Properties[0].Value)
+		assert.NotEmpty(t, created.Properties[0].EncryptedValue)
+
+		other := gocdtest.NewServer()
+		defer other.Close()
+
+		created.ETAG = ""
+
+		_, err = other.GoCdClient().CreateAuthConfig(created)
+
+		validationErr, ok := errors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, map[string][]string{
+			"properties": {"Encrypted value for property with key 'Password' is invalid. " +
+				"This usually happens when the cipher text is modified to have an invalid value."},
+		}, validationErr.Fields)
+	})
+}
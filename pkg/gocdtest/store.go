@@ -0,0 +1,90 @@
+package gocdtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// store holds the objects of a kind served by the fake server in the order they were added.
+type store[T any] struct {
+	kind  string
+	key   func(T) string
+	items map[string]T
+	order []string
+}
+
+func newStore[T any](kind string, key func(T) string) *store[T] {
+	return &store[T]{
+		kind:  kind,
+		key:   key,
+		items: make(map[string]T),
+	}
+}
+
+func (s *store[T]) get(name string) (T, bool) {
+	item, ok := s.items[name]
+
+	return item, ok
+}
+
+// put adds or replaces the object, ETAG is dropped as it is computed from the content when served.
+func (s *store[T]) put(item T) {
+	item = withoutETag(item)
+	name := s.key(item)
+
+	if _, ok := s.items[name]; !ok {
+		s.order = append(s.order, name)
+	}
+
+	s.items[name] = item
+}
+
+func (s *store[T]) remove(name string) bool {
+	if _, ok := s.items[name]; !ok {
+		return false
+	}
+
+	delete(s.items, name)
+
+	for index, key := range s.order {
+		if key == name {
+			s.order = append(s.order[:index], s.order[index+1:]...)
+
+			break
+		}
+	}
+
+	return true
+}
+
+func (s *store[T]) list() []T {
+	items := make([]T, 0, len(s.order))
+	for _, name := range s.order {
+		items = append(items, s.items[name])
+	}
+
+	return items
+}
+
+// etag computes the ETag of the object from its content, so that any change to the object changes the ETag.
+func etag(item any) string {
+	content, _ := json.Marshal(item)
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func withoutETag[T any](item T) T {
+	value := reflect.ValueOf(&item).Elem()
+	if value.Kind() != reflect.Struct {
+		return item
+	}
+
+	if field := value.FieldByName("ETAG"); field.IsValid() && field.CanSet() && field.Kind() == reflect.String {
+		field.SetString("")
+	}
+
+	return item
+}
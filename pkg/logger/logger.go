@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Logger is the logger used across the SDK, it is also set as the logger of resty so that its debug output,
+// which dumps the requests and responses, is routed through it. The secrets are redacted from every message logged.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// Redacted replaces the secrets in the messages logged.
+const Redacted = "[REDACTED]"
+
+var (
+	// secretHeaders matches the headers carrying credentials as dumped by resty, one header per line.
+	secretHeaders = regexp.MustCompile(`(?im)^(\s*(?:authorization|proxy-authorization|cookie|set-cookie)\s*:\s*).+$`)
+	// secretFields matches the JSON fields holding secrets, e.g. password or encrypted_value.
+	secretFields = regexp.MustCompile(`(?i)("[a-z_]*(?:password|secret|token|encrypted_value)[a-z_]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// secureObjects matches the JSON objects of the secure properties and environment variables, that hold no other object.
+	secureObjects = regexp.MustCompile(`\{[^{}]*"(?:secure|is_secure)"\s*:\s*true[^{}]*\}`)
+	// plainValues matches the value field of the JSON object, holding the plain text of the secure ones.
+	plainValues = regexp.MustCompile(`("value"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+type logrusLogger struct {
+	logger *log.Logger
+}
+
+// NewSlog returns the Logger that logs to the slog.Logger, the messages are logged only at the levels enabled on it.
+func NewSlog(logger *slog.Logger) Logger {
+	return &slogLogger{logger: logger}
+}
+
+// NewLogrus returns the Logger that logs to the logrus.Logger.
+func NewLogrus(logger *log.Logger) Logger {
+	return &logrusLogger{logger: logger}
+}
+
+// Redact replaces the values of the headers carrying credentials, the JSON fields holding secrets
+// and the values of the secure properties and environment variables in the message.
+func Redact(message string) string {
+	message = secretHeaders.ReplaceAllString(message, "${1}"+Redacted)
+	message = secureObjects.ReplaceAllStringFunc(message, func(object string) string {
+		return plainValues.ReplaceAllString(object, `${1}"`+Redacted+`"`)
+	})
+
+	return secretFields.ReplaceAllString(message, `${1}"`+Redacted+`"`)
+}
+
+func (logger *slogLogger) Debugf(format string, args ...any) {
+	logger.log(slog.LevelDebug, format, args...)
+}
+
+func (logger *slogLogger) Infof(format string, args ...any) {
+	logger.log(slog.LevelInfo, format, args...)
+}
+
+func (logger *slogLogger) Warnf(format string, args ...any) {
+	logger.log(slog.LevelWarn, format, args...)
+}
+
+func (logger *slogLogger) Errorf(format string, args ...any) {
+	logger.log(slog.LevelError, format, args...)
+}
+
+func (logger *slogLogger) log(level slog.Level, format string, args ...any) {
+	if !logger.logger.Enabled(context.Background(), level) {
+		return
+	}
+
+	logger.logger.Log(context.Background(), level, Redact(fmt.Sprintf(format, args...)))
+}
+
+func (logger *logrusLogger) Debugf(format string, args ...any) {
+	logger.log(log.DebugLevel, format, args...)
+}
+
+func (logger *logrusLogger) Infof(format string, args ...any) {
+	logger.log(log.InfoLevel, format, args...)
+}
+
+func (logger *logrusLogger) Warnf(format string, args ...any) {
+	logger.log(log.WarnLevel, format, args...)
+}
+
+func (logger *logrusLogger) Errorf(format string, args ...any) {
+	logger.log(log.ErrorLevel, format, args...)
+}
+
+func (logger *logrusLogger) log(level log.Level, format string, args ...any) {
+	if !logger.logger.IsLevelEnabled(level) {
+		return
+	}
+
+	logger.logger.Log(level, Redact(fmt.Sprintf(format, args...)))
+}
@@ -0,0 +1,69 @@
+package logger_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/logger"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	t.Run("should redact the headers carrying credentials", func(t *testing.T) {
+		actual := logger.Redact("HEADERS:\n\tAccept: application/json\n\tAuthorization: Basic YWRtaW46YWRtaW4=\n\tCookie: JSESSIONID=42")
+		assert.Equal(t, "HEADERS:\n\tAccept: application/json\n\tAuthorization: [REDACTED]\n\tCookie: [REDACTED]", actual)
+	})
+
+	t.Run("should redact the JSON fields holding secrets", func(t *testing.T) {
+		actual := logger.Redact(`{"username": "admin", "encrypted_password": "AES:x\"y", "properties": [{"key": "Token", "encrypted_value": "AES:z"}]}`)
+		assert.Equal(t, `{"username": "admin", "encrypted_password": "[REDACTED]", "properties": [{"key": "Token", "encrypted_value": "[REDACTED]"}]}`, actual)
+	})
+
+	t.Run("should redact the values of the secure properties and environment variables", func(t *testing.T) {
+		actual := logger.Redact(`{"properties": [{"key": "Url", "value": "ldap://ldap"}, {"key": "Password", "value": "a\"b", "secure": true}],
+"environment_variables": [{"name": "DB_PASS", "value": "admin", "secure": true}, {"name": "DB_USER", "value": "admin", "secure": false}],
+"configuration": [{"is_secure": true, "key": "Token", "value": "admin"}]}`)
+		assert.Equal(t, `{"properties": [{"key": "Url", "value": "ldap://ldap"}, {"key": "Password", "value": "[REDACTED]", "secure": true}],
+"environment_variables": [{"name": "DB_PASS", "value": "[REDACTED]", "secure": true}, {"name": "DB_USER", "value": "admin", "secure": false}],
+"configuration": [{"is_secure": true, "key": "Token", "value": "[REDACTED]"}]}`, actual)
+	})
+}
+
+func TestNewSlog(t *testing.T) {
+	t.Run("should log to the slog logger only at the levels enabled with the secrets redacted", func(t *testing.T) {
+		var out bytes.Buffer
+
+		slogger := logger.NewSlog(slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{
+			Level:       slog.LevelInfo,
+			ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr { return dropTime(attr) },
+		})))
+		slogger.Debugf("not logged")
+		slogger.Warnf("body %s", `{"password": "admin"}`)
+
+		assert.Equal(t, `level=WARN msg="body {\"password\": \"[REDACTED]\"}"`+"\n", out.String())
+	})
+}
+
+func TestNewLogrus(t *testing.T) {
+	t.Run("should log to the logrus logger with the secrets redacted", func(t *testing.T) {
+		var out bytes.Buffer
+
+		logrusLogger := log.New()
+		logrusLogger.SetOutput(&out)
+		logrusLogger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+
+		logger.NewLogrus(logrusLogger).Errorf("body %s", `{"secret": "admin"}`)
+
+		assert.Equal(t, `level=error msg="body {\"secret\": \"[REDACTED]\"}"`+"\n", out.String())
+	})
+}
+
+func dropTime(attr slog.Attr) slog.Attr {
+	if attr.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+
+	return attr
+}
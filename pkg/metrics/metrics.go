@@ -0,0 +1,255 @@
+// Package metrics exposes the state of GoCD as metrics in the OpenMetrics text format, scrapable by Prometheus.
+//
+// The Collector reads the agents, scheduled jobs, pipeline groups, server health messages, config repos,
+// maintenance mode and optionally a backup through the GoCd client on every collection, so no state is kept
+// between the scrapes. It needs no dependencies other than the SDK, the metrics are written by WriteOpenMetrics.
+package metrics
+
+import (
+	goErrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+const namespace = "gocd"
+
+// Collector collects the metrics of GoCD using the client.
+type Collector struct {
+	client   gocd.GoCd
+	backupID string
+}
+
+// Option configures the Collector.
+type Option func(*Collector)
+
+// source is a part of GoCD read by the Collector, it returns the families of the metrics read from it.
+type source struct {
+	name    string
+	collect func() ([]Family, error)
+}
+
+// WithBackupID exposes the status of the backup with the ID, e.g. of the latest backup scheduled using ScheduleBackup.
+// GoCD has no API to look up the latest backup, so it is not exposed by default.
+func WithBackupID(id string) Option {
+	return func(collector *Collector) {
+		collector.backupID = id
+	}
+}
+
+// New returns the Collector that reads the state of GoCD using the client.
+func New(client gocd.GoCd, opts ...Option) *Collector {
+	collector := &Collector{client: client}
+
+	for _, opt := range opts {
+		opt(collector)
+	}
+
+	return collector
+}
+
+// Collect reads the state of GoCD and returns it as metrics. A part of GoCD that could not be read is skipped,
+// it is reported as 0 by the metric gocd_up and the errors are returned along with the rest of the metrics.
+func (collector *Collector) Collect() ([]Family, error) {
+	families := make([]Family, 0)
+	errs := make([]error, 0)
+	up := make(map[string]float64)
+
+	for _, src := range collector.sources() {
+		collected, err := src.collect()
+		if err != nil {
+			errs = append(errs, &errors.GoCDError{Message: fmt.Sprintf("collecting %s metrics errored with", src.name), Err: err})
+			up[src.name] = 0
+
+			continue
+		}
+
+		up[src.name] = 1
+		families = append(families, collected...)
+	}
+
+	families = append(families, gauge(name("up"), "Whether the metrics could be collected from the source.", []string{"source"}, up))
+
+	return families, goErrors.Join(errs...)
+}
+
+// WriteTo collects the metrics and writes them in the OpenMetrics text format.
+// The metrics collected are written even if some of the sources errored, see Collect.
+func (collector *Collector) WriteTo(writer io.Writer) (int64, error) {
+	families, collectErr := collector.Collect()
+
+	written, err := WriteOpenMetrics(writer, families)
+	if err != nil {
+		return written, err
+	}
+
+	return written, collectErr
+}
+
+// ServeHTTP serves the metrics in the OpenMetrics text format, so that the Collector could be registered as the handler of /metrics.
+// Errors collecting the metrics are reported by the metric gocd_up, not by the status code.
+func (collector *Collector) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	families, _ := collector.Collect()
+
+	writer.Header().Set("Content-Type", ContentType)
+	_, _ = WriteOpenMetrics(writer, families)
+}
+
+func (collector *Collector) sources() []source {
+	sources := []source{
+		{name: "agents", collect: collector.agents},
+		{name: "scheduled_jobs", collect: collector.scheduledJobs},
+		{name: "pipeline_groups", collect: collector.pipelineGroups},
+		{name: "server_health", collect: collector.serverHealth},
+		{name: "config_repos", collect: collector.configRepos},
+		{name: "maintenance_mode", collect: collector.maintenance},
+	}
+
+	if len(collector.backupID) != 0 {
+		sources = append(sources, source{name: "backup", collect: collector.backup})
+	}
+
+	return sources
+}
+
+func (collector *Collector) agents() ([]Family, error) {
+	agents, err := collector.client.GetAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]float64)
+	resources := make(map[string]float64)
+
+	for _, agent := range agents {
+		states[key(agent.CurrentState, agent.ConfigState, agent.OS)]++
+
+		for _, resource := range agent.Resources {
+			resources[resource]++
+		}
+	}
+
+	return []Family{
+		gauge(name("agents"), "Number of agents by state, config state and operating system.", []string{"state", "config_state", "os"}, states),
+		gauge(name("agent_resources"), "Number of agents having the resource.", []string{"resource"}, resources),
+	}, nil
+}
+
+func (collector *Collector) scheduledJobs() ([]Family, error) {
+	jobs, err := collector.client.GetScheduledJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	return []Family{
+		gauge(name("scheduled_jobs"), "Number of jobs scheduled and waiting to be assigned to an agent.", nil,
+			map[string]float64{"": float64(len(jobs.Job))}),
+	}, nil
+}
+
+func (collector *Collector) pipelineGroups() ([]Family, error) {
+	groups, err := collector.client.GetPipelineGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make(map[string]float64, len(groups))
+
+	for _, group := range groups {
+		count := group.PipelineCount
+		if len(group.Pipelines) != 0 {
+			count = len(group.Pipelines)
+		}
+
+		pipelines[group.Name] = float64(count)
+	}
+
+	return []Family{
+		gauge(name("pipelines"), "Number of pipelines by pipeline group.", []string{"group"}, pipelines),
+	}, nil
+}
+
+func (collector *Collector) serverHealth() ([]Family, error) {
+	messages, err := collector.client.GetServerHealthMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[string]float64)
+	for _, message := range messages {
+		levels[message.Level]++
+	}
+
+	return []Family{
+		gauge(name("server_health_messages"), "Number of server health messages by level.", []string{"level"}, levels),
+	}, nil
+}
+
+func (collector *Collector) configRepos() ([]Family, error) {
+	repos, err := collector.client.GetConfigReposInternal()
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make(map[string]float64, len(repos))
+
+	for _, repo := range repos {
+		failed[repo.ID] = 0
+		if len(repo.ConfigRepoParseInfo.Error) != 0 {
+			failed[repo.ID] = 1
+		}
+	}
+
+	return []Family{
+		gauge(name("config_repo_parse_failed"), "Whether the latest parse of the config repo failed.", []string{"repo"}, failed),
+	}, nil
+}
+
+func (collector *Collector) maintenance() ([]Family, error) {
+	maintenance, err := collector.client.GetMaintenanceModeInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	var enabled float64
+	if maintenance.MaintenanceInfo.Enabled {
+		enabled = 1
+	}
+
+	return []Family{
+		gauge(name("maintenance_mode"), "Whether the server is in maintenance mode.", nil, map[string]float64{"": enabled}),
+	}, nil
+}
+
+func (collector *Collector) backup() ([]Family, error) {
+	backup, err := collector.client.GetBackup(collector.backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	families := []Family{
+		gauge(name("backup_status"), "Status of the backup, the sample with the current status is 1.", []string{"id", "status"},
+			map[string]float64{key(collector.backupID, backup.Status): 1}),
+	}
+
+	if taken, err := time.Parse(time.RFC3339, backup.Time); err == nil {
+		families = append(families, gauge(name("backup_timestamp_seconds"), "Time at which the backup was taken, in seconds since epoch.",
+			[]string{"id"}, map[string]float64{collector.backupID: float64(taken.Unix())}))
+	}
+
+	return families, nil
+}
+
+func name(metric string) string {
+	return namespace + "_" + metric
+}
+
+// key joins the values of the labels to key the counts passed to gauge.
+func key(values ...string) string {
+	return strings.Join(values, labelSeparator)
+}
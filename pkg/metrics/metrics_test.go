@@ -0,0 +1,153 @@
+package metrics_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient serves the state of GoCD set in its fields, the rest of the methods of gocd.GoCd are not implemented.
+type fakeClient struct {
+	gocd.GoCd
+	agents []gocd.Agent
+	err    error
+}
+
+func (client *fakeClient) GetAgents() ([]gocd.Agent, error) {
+	return client.agents, client.err
+}
+
+func (client *fakeClient) GetScheduledJobs() (gocd.ScheduledJobs, error) {
+	return gocd.ScheduledJobs{Job: []gocd.Job{{Name: "test"}, {Name: "build"}}}, nil
+}
+
+func (client *fakeClient) GetPipelineGroups() ([]gocd.PipelineGroup, error) {
+	return []gocd.PipelineGroup{
+		{Name: "first", Pipelines: []gocd.Pipeline{{Name: "up42"}, {Name: "up43"}}},
+		{Name: "second", PipelineCount: 1},
+	}, nil
+}
+
+func (client *fakeClient) GetServerHealthMessages() ([]gocd.ServerHealth, error) {
+	return []gocd.ServerHealth{{Level: "WARNING"}, {Level: "ERROR"}, {Level: "WARNING"}}, nil
+}
+
+func (client *fakeClient) GetConfigReposInternal() ([]gocd.ConfigRepo, error) {
+	return []gocd.ConfigRepo{
+		{ID: "repo-1"},
+		{ID: "repo-2", ConfigRepoParseInfo: gocd.ConfigRepoParseInfo{Error: "invalid yaml"}},
+	}, nil
+}
+
+func (client *fakeClient) GetMaintenanceModeInfo() (gocd.Maintenance, error) {
+	var maintenance gocd.Maintenance
+	maintenance.MaintenanceInfo.Enabled = true
+
+	return maintenance, nil
+}
+
+func (client *fakeClient) GetBackup(_ string) (gocd.BackupStats, error) {
+	return gocd.BackupStats{Status: "COMPLETED", Time: "2024-02-10T10:00:00Z"}, nil
+}
+
+func TestCollector_WriteTo(t *testing.T) {
+	t.Run("should write the metrics of GoCD in OpenMetrics text format", func(t *testing.T) {
+		client := &fakeClient{agents: []gocd.Agent{
+			{ID: "agent-1", CurrentState: "Idle", ConfigState: "Enabled", OS: "Linux", Resources: []string{"docker", "java"}},
+			{ID: "agent-2", CurrentState: "Building", ConfigState: "Enabled", OS: "Linux", Resources: []string{"docker"}},
+			{ID: "agent-3", CurrentState: "Idle", ConfigState: "Enabled", OS: "Linux"},
+		}}
+
+		var out strings.Builder
+
+		_, err := metrics.New(client, metrics.WithBackupID("backup-1")).WriteTo(&out)
+		require.NoError(t, err)
+
+		assert.Equal(t, `# TYPE gocd_agents gauge
+# HELP gocd_agents Number of agents by state, config state and operating system.
+gocd_agents{state="Building",config_state="Enabled",os="Linux"} 1
+gocd_agents{state="Idle",config_state="Enabled",os="Linux"} 2
+# TYPE gocd_agent_resources gauge
+# HELP gocd_agent_resources Number of agents having the resource.
+gocd_agent_resources{resource="docker"} 2
+gocd_agent_resources{resource="java"} 1
+# TYPE gocd_scheduled_jobs gauge
+# HELP gocd_scheduled_jobs Number of jobs scheduled and waiting to be assigned to an agent.
+gocd_scheduled_jobs 2
+# TYPE gocd_pipelines gauge
+# HELP gocd_pipelines Number of pipelines by pipeline group.
+gocd_pipelines{group="first"} 2
+gocd_pipelines{group="second"} 1
+# TYPE gocd_server_health_messages gauge
+# HELP gocd_server_health_messages Number of server health messages by level.
+gocd_server_health_messages{level="ERROR"} 1
+gocd_server_health_messages{level="WARNING"} 2
+# TYPE gocd_config_repo_parse_failed gauge
+# HELP gocd_config_repo_parse_failed Whether the latest parse of the config repo failed.
+gocd_config_repo_parse_failed{repo="repo-1"} 0
+gocd_config_repo_parse_failed{repo="repo-2"} 1
+# TYPE gocd_maintenance_mode gauge
+# HELP gocd_maintenance_mode Whether the server is in maintenance mode.
+gocd_maintenance_mode 1
+# TYPE gocd_backup_status gauge
+# HELP gocd_backup_status Status of the backup, the sample with the current status is 1.
+gocd_backup_status{id="backup-1",status="COMPLETED"} 1
+# TYPE gocd_backup_timestamp_seconds gauge
+# HELP gocd_backup_timestamp_seconds Time at which the backup was taken, in seconds since epoch.
+gocd_backup_timestamp_seconds{id="backup-1"} 1707559200
+# TYPE gocd_up gauge
+# HELP gocd_up Whether the metrics could be collected from the source.
+gocd_up{source="agents"} 1
+gocd_up{source="backup"} 1
+gocd_up{source="config_repos"} 1
+gocd_up{source="maintenance_mode"} 1
+gocd_up{source="pipeline_groups"} 1
+gocd_up{source="scheduled_jobs"} 1
+gocd_up{source="server_health"} 1
+# EOF
+`, out.String())
+	})
+
+	t.Run("should report the sources that could not be collected as down and serve the rest", func(t *testing.T) {
+		client := &fakeClient{err: fmt.Errorf("connection refused")} //nolint:goerr113
+		collector := metrics.New(client)
+
+		_, err := collector.Collect()
+		require.EqualError(t, err, "collecting agents metrics errored with connection refused")
+
+		recorder := httptest.NewRecorder()
+		collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, metrics.ContentType, recorder.Header().Get("Content-Type"))
+		assert.NotContains(t, recorder.Body.String(), "gocd_agents")
+		assert.Contains(t, recorder.Body.String(), "gocd_up{source=\"agents\"} 0\n")
+		assert.Contains(t, recorder.Body.String(), "gocd_maintenance_mode 1\n")
+	})
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	t.Run("should escape the help and the label values", func(t *testing.T) {
+		var out strings.Builder
+
+		_, err := metrics.WriteOpenMetrics(&out, []metrics.Family{{
+			Name:    "gocd_server_health_messages",
+			Help:    "Messages\nby level.",
+			Samples: []metrics.Sample{{Labels: []metrics.Label{{Name: "level", Value: `say "hi" \ there`}}, Value: 0.5}},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, `# TYPE gocd_server_health_messages gauge
+# HELP gocd_server_health_messages Messages\nby level.
+gocd_server_health_messages{level="say \"hi\" \\ there"} 0.5
+# EOF
+`, out.String())
+	})
+}
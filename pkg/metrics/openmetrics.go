@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the metrics written in the OpenMetrics text format.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// labelSeparator joins the values of the labels to key the counts of the samples.
+const labelSeparator = "\x00"
+
+// Family is a metric along with its samples, all the metrics of GoCD are gauges.
+type Family struct {
+	Name    string
+	Help    string
+	Samples []Sample
+}
+
+// Sample is a value of the metric for a set of labels.
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Label is a name and value pair distinguishing the samples of a metric.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// WriteOpenMetrics writes the families in the OpenMetrics text format, terminated by '# EOF'.
+func WriteOpenMetrics(writer io.Writer, families []Family) (int64, error) {
+	var builder strings.Builder
+
+	for _, family := range families {
+		fmt.Fprintf(&builder, "# TYPE %s gauge\n", family.Name)
+		fmt.Fprintf(&builder, "# HELP %s %s\n", family.Name, escape(family.Help, false))
+
+		for _, sample := range family.Samples {
+			builder.WriteString(family.Name)
+
+			if len(sample.Labels) != 0 {
+				labels := make([]string, 0, len(sample.Labels))
+				for _, label := range sample.Labels {
+					labels = append(labels, fmt.Sprintf(`%s="%s"`, label.Name, escape(label.Value, true)))
+				}
+
+				builder.WriteString("{" + strings.Join(labels, ",") + "}")
+			}
+
+			builder.WriteString(" " + strconv.FormatFloat(sample.Value, 'f', -1, 64) + "\n")
+		}
+	}
+
+	builder.WriteString("# EOF\n")
+
+	written, err := io.WriteString(writer, builder.String())
+
+	return int64(written), err
+}
+
+// escape escapes the backslashes and new lines, along with the double quotes in the label values.
+func escape(value string, quote bool) string {
+	replacements := []string{`\`, `\\`, "\n", `\n`}
+	if quote {
+		replacements = append(replacements, `"`, `\"`)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(value)
+}
+
+// gauge builds the family from the counts keyed by the values of the labels, the samples are sorted by the label values.
+func gauge(name, help string, labels []string, counts map[string]float64) Family {
+	family := Family{Name: name, Help: help, Samples: make([]Sample, 0, len(counts))}
+
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		values := strings.Split(key, labelSeparator)
+		sample := Sample{Labels: make([]Label, 0, len(labels)), Value: counts[key]}
+
+		for index, label := range labels {
+			sample.Labels = append(sample.Labels, Label{Name: label, Value: values[index]})
+		}
+
+		family.Samples = append(family.Samples, sample)
+	}
+
+	return family
+}
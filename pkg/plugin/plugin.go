@@ -3,6 +3,7 @@ package plugin
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -40,7 +41,7 @@ type Config struct {
 	Version      string `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version"`
 	Path         string `json:"path,omitempty"    yaml:"path,omitempty"    mapstructure:"path"`
 	URL          string `json:"url,omitempty"     yaml:"url,omitempty"     mapstructure:"url"`
-	log          *log.Logger
+	log          goCdLogger.Logger
 	PipelineType string
 }
 
@@ -129,7 +130,7 @@ func (cfg *Config) GetLatestRelease(pluginURL string) (string, error) {
 
 	cfg.log.Debugf("fetching latest version information using '%s'", pluginURL)
 
-	httpClient := resty.New()
+	httpClient := resty.New().SetLogger(cfg.log)
 
 	resp, err := httpClient.R().Get(pluginURL)
 	if err != nil {
@@ -234,7 +235,7 @@ func (cfg *Config) Download() (string, error) {
 
 	cfg.log.Debugf("downloading plugin under '%s'", pluginLocalPath)
 
-	httpClient := resty.New()
+	httpClient := resty.New().SetLogger(cfg.log)
 
 	resp, err := httpClient.R().
 		SetOutput(pluginLocalPath).
@@ -263,7 +264,17 @@ func NewPluginConfig(version, path, url, loglevel string) Plugin {
 	logger.SetFormatter(&log.JSONFormatter{})
 
 	return &Config{
-		log:     logger,
+		log:     goCdLogger.NewLogrus(logger),
+		Version: version,
+		Path:    path,
+		URL:     url,
+	}
+}
+
+// NewPluginConfigWithLogger is same as NewPluginConfig, except that all the logs are routed to the slog.Logger passed.
+func NewPluginConfigWithLogger(version, path, url string, logger *slog.Logger) Plugin {
+	return &Config{
+		log:     goCdLogger.NewSlog(logger),
 		Version: version,
 		Path:    path,
 		URL:     url,
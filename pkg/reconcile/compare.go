@@ -0,0 +1,146 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// secureFields are the fields of the secure properties and environment variables that GoCD does not return,
+// as it returns only the value encrypted with its cipher key under 'encrypted_value'.
+var secureFields = map[string]bool{"value": true, "secure": true, "is_secure": true}
+
+// differs reports whether any of the fields set on the desired object differs from the current object, ETag is ignored.
+// Lists are compared element by element, so a list set on the desired object has to match the current list entirely.
+// The plain text value of a secure property is taken to match the encrypted value held by GoCD, as it could not be decrypted
+// to compare, hence a change only in the value of a secure property is not detected, unless secretUpdates is set,
+// in which case the plain text value is always taken to differ.
+func differs(current, desired any, secretUpdates bool) (bool, error) {
+	currentValue, err := toValue(current)
+	if err != nil {
+		return false, err
+	}
+
+	desiredValue, err := toValue(desired)
+	if err != nil {
+		return false, err
+	}
+
+	return !subset(desiredValue, currentValue, secretUpdates), nil
+}
+
+// overlay sets the fields set on the desired object over the current object,
+// so that the fields not managed by the desired state are retained while updating the object.
+func overlay[T any](current, desired T) (T, error) {
+	var merged T
+
+	currentValue, err := toValue(current)
+	if err != nil {
+		return merged, err
+	}
+
+	desiredValue, err := toValue(desired)
+	if err != nil {
+		return merged, err
+	}
+
+	content, err := json.Marshal(mergeValues(currentValue, desiredValue))
+	if err != nil {
+		return merged, err
+	}
+
+	err = json.Unmarshal(content, &merged)
+
+	return merged, err
+}
+
+func toValue(object any) (any, error) {
+	content, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err = json.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+
+	if fields, ok := value.(map[string]any); ok {
+		delete(fields, "etag")
+	}
+
+	return value, nil
+}
+
+func subset(desired, current any, secretUpdates bool) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		currentValue, ok := current.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		encrypted := isEncrypted(desiredValue, currentValue)
+
+		for key, value := range desiredValue {
+			if encrypted && secureFields[key] {
+				if plainValue, _ := value.(string); secretUpdates && key == "value" && len(plainValue) != 0 {
+					return false
+				}
+
+				continue
+			}
+
+			if !subset(value, currentValue[key], secretUpdates) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		currentValue, ok := current.([]any)
+		if !ok || len(currentValue) != len(desiredValue) {
+			return false
+		}
+
+		for index := range desiredValue {
+			if !subset(desiredValue[index], currentValue[index], secretUpdates) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(desired, current)
+	}
+}
+
+func mergeValues(current, desired any) any {
+	currentValue, currentIsMap := current.(map[string]any)
+	desiredValue, desiredIsMap := desired.(map[string]any)
+
+	if !currentIsMap || !desiredIsMap {
+		return desired
+	}
+
+	if _, ok := desiredValue["value"]; ok && isEncrypted(desiredValue, currentValue) {
+		delete(currentValue, "encrypted_value")
+	}
+
+	for key, value := range desiredValue {
+		currentValue[key] = mergeValues(currentValue[key], value)
+	}
+
+	return currentValue
+}
+
+// isEncrypted reports whether the current object is a secure property or environment variable holding the encrypted value,
+// and the desired one sets its plain text value instead.
+func isEncrypted(desired, current map[string]any) bool {
+	if _, ok := desired["encrypted_value"]; ok {
+		return false
+	}
+
+	encryptedValue, _ := current["encrypted_value"].(string)
+
+	return len(encryptedValue) != 0
+}
@@ -0,0 +1,307 @@
+package reconcile
+
+import (
+	"fmt"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// handler computes and applies the changes for a kind of object.
+type handler interface {
+	kind() Kind
+	// changes returns the objects to be created or updated, and the objects to be deleted when pruning.
+	changes(state State, prune, secretUpdates bool) ([]Change, []Change, error)
+	apply(change Change) error
+}
+
+// resource implements the handler for objects of type T using the functions of the GoCd client.
+type resource[T any] struct {
+	name    Kind
+	desired func(state State) []T
+	key     func(item T) string
+	eTag    func(item T) string
+	list    func() ([]T, error)
+	get     func(name string) (T, error)
+	create  func(item T) error
+	update  func(item T, eTag string) error
+	remove  func(name string) error
+}
+
+func (r *resource[T]) kind() Kind {
+	return r.name
+}
+
+func (r *resource[T]) changes(state State, prune, secretUpdates bool) ([]Change, []Change, error) {
+	desired := r.desired(state)
+	if desired == nil {
+		return nil, nil, nil
+	}
+
+	current, err := r.list()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currentByName := make(map[string]T, len(current))
+	for _, item := range current {
+		currentByName[r.key(item)] = item
+	}
+
+	changes := make([]Change, 0)
+	desiredNames := make(map[string]bool, len(desired))
+
+	for _, item := range desired {
+		name := r.key(item)
+		if len(name) == 0 {
+			return nil, nil, &errors.GoCDSDKError{Message: fmt.Sprintf("%s without name or id found in desired state", r.name)}
+		}
+
+		if desiredNames[name] {
+			return nil, nil, &errors.GoCDSDKError{Message: fmt.Sprintf("%s '%s' is defined more than once in desired state", r.name, name)}
+		}
+
+		desiredNames[name] = true
+
+		existing, found := currentByName[name]
+		if !found {
+			changes = append(changes, Change{Kind: r.name, Name: name, Action: ActionCreate, Desired: item})
+
+			continue
+		}
+
+		change, err := r.updateChange(name, existing, item, secretUpdates)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	deletes := make([]Change, 0)
+
+	if prune {
+		for _, item := range current {
+			if name := r.key(item); !desiredNames[name] {
+				deletes = append(deletes, Change{Kind: r.name, Name: name, Action: ActionDelete, Current: item})
+			}
+		}
+	}
+
+	return changes, deletes, nil
+}
+
+// updateChange returns the change to update the object when it differs from the desired one, nil otherwise.
+// The listed objects do not carry their ETag, hence the object is fetched again before computing the change.
+func (r *resource[T]) updateChange(name string, listed, desired T, secretUpdates bool) (*Change, error) {
+	differ, err := differs(listed, desired, secretUpdates)
+	if err != nil || !differ {
+		return nil, err
+	}
+
+	current, err := r.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if differ, err = differs(current, desired, secretUpdates); err != nil || !differ {
+		return nil, err
+	}
+
+	merged, err := overlay(current, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Change{Kind: r.name, Name: name, Action: ActionUpdate, Current: current, Desired: merged, ETag: r.eTag(current)}, nil
+}
+
+func (r *resource[T]) apply(change Change) error {
+	if change.Action == ActionDelete {
+		return r.remove(change.Name)
+	}
+
+	item, ok := change.Desired.(T)
+	if !ok {
+		return &errors.GoCDSDKError{Message: fmt.Sprintf("desired object of %s '%s' is of type %T, expected %T", r.name, change.Name, change.Desired, item)}
+	}
+
+	switch change.Action {
+	case ActionCreate:
+		return r.create(item)
+	case ActionUpdate:
+		return r.update(item, change.ETag)
+	default:
+		return &errors.GoCDSDKError{Message: fmt.Sprintf("unknown action '%s' for %s '%s'", change.Action, r.name, change.Name)}
+	}
+}
+
+// handlers returns the handlers of all the kinds in the order of their dependencies,
+// objects are created and updated in this order and deleted in the reverse order.
+func handlers(client gocd.GoCd) []handler {
+	return []handler{
+		&resource[gocd.CommonConfig]{
+			name:    KindAuthConfig,
+			desired: func(state State) []gocd.CommonConfig { return state.AuthConfigs },
+			key:     configID,
+			eTag:    configETag,
+			list:    client.GetAuthConfigs,
+			get:     client.GetAuthConfig,
+			create:  discard(client.CreateAuthConfig),
+			update: func(config gocd.CommonConfig, eTag string) error {
+				config.ETAG = eTag
+				_, err := client.UpdateAuthConfig(config)
+
+				return err
+			},
+			remove: client.DeleteAuthConfig,
+		},
+		&resource[gocd.Role]{
+			name:    KindRole,
+			desired: func(state State) []gocd.Role { return state.Roles },
+			key:     func(role gocd.Role) string { return role.Name },
+			eTag:    func(role gocd.Role) string { return role.ETAG },
+			list: func() ([]gocd.Role, error) {
+				roles, err := client.GetRoles()
+
+				return roles.Role, err
+			},
+			get:    client.GetRole,
+			create: discard(client.CreateRole),
+			update: func(role gocd.Role, eTag string) error {
+				role.ETAG = eTag
+				_, err := client.UpdateRole(role)
+
+				return err
+			},
+			remove: client.DeleteRole,
+		},
+		&resource[gocd.CommonConfig]{
+			name:    KindSecretConfig,
+			desired: func(state State) []gocd.CommonConfig { return state.SecretConfigs },
+			key:     configID,
+			eTag:    configETag,
+			list: func() ([]gocd.CommonConfig, error) {
+				configs, err := client.GetSecretConfigs()
+
+				return configs.CommonConfigs, err
+			},
+			get:    client.GetSecretConfig,
+			create: discard(client.CreateSecretConfig),
+			update: func(config gocd.CommonConfig, eTag string) error {
+				config.ETAG = eTag
+				_, err := client.UpdateSecretConfig(config)
+
+				return err
+			},
+			remove: client.DeleteSecretConfig,
+		},
+		&resource[gocd.CommonConfig]{
+			name:    KindClusterProfile,
+			desired: func(state State) []gocd.CommonConfig { return state.ClusterProfiles },
+			key:     configID,
+			eTag:    configETag,
+			list: func() ([]gocd.CommonConfig, error) {
+				profiles, err := client.GetClusterProfiles()
+
+				return append(profiles.CommonConfigs, profiles.ClusterProfilesConfig...), err
+			},
+			get:    client.GetClusterProfile,
+			create: discard(client.CreateClusterProfile),
+			update: func(config gocd.CommonConfig, eTag string) error {
+				config.ETAG = eTag
+				_, err := client.UpdateClusterProfile(config)
+
+				return err
+			},
+			remove: client.DeleteClusterProfile,
+		},
+		&resource[gocd.CommonConfig]{
+			name:    KindElasticAgentProfile,
+			desired: func(state State) []gocd.CommonConfig { return state.ElasticAgentProfiles },
+			key:     configID,
+			eTag:    configETag,
+			list: func() ([]gocd.CommonConfig, error) {
+				profiles, err := client.GetElasticAgentProfiles()
+
+				return profiles.CommonConfigs, err
+			},
+			get:    client.GetElasticAgentProfile,
+			create: discard(client.CreateElasticAgentProfile),
+			update: func(config gocd.CommonConfig, eTag string) error {
+				config.ETAG = eTag
+				_, err := client.UpdateElasticAgentProfile(config)
+
+				return err
+			},
+			remove: client.DeleteElasticAgentProfile,
+		},
+		&resource[gocd.PipelineGroup]{
+			name:    KindPipelineGroup,
+			desired: func(state State) []gocd.PipelineGroup { return state.PipelineGroups },
+			key:     func(group gocd.PipelineGroup) string { return group.Name },
+			eTag:    func(group gocd.PipelineGroup) string { return group.ETAG },
+			list:    client.GetPipelineGroups,
+			get:     client.GetPipelineGroup,
+			create:  client.CreatePipelineGroup,
+			update: func(group gocd.PipelineGroup, eTag string) error {
+				group.ETAG = eTag
+				_, err := client.UpdatePipelineGroup(group)
+
+				return err
+			},
+			remove: client.DeletePipelineGroup,
+		},
+		&resource[gocd.Environment]{
+			name:    KindEnvironment,
+			desired: func(state State) []gocd.Environment { return state.Environments },
+			key:     func(environment gocd.Environment) string { return environment.Name },
+			eTag:    func(environment gocd.Environment) string { return environment.ETAG },
+			list:    client.GetEnvironments,
+			get:     client.GetEnvironment,
+			create:  client.CreateEnvironment,
+			update: func(environment gocd.Environment, eTag string) error {
+				environment.ETAG = eTag
+				_, err := client.UpdateEnvironment(environment)
+
+				return err
+			},
+			remove: client.DeleteEnvironment,
+		},
+		&resource[gocd.ConfigRepo]{
+			name:    KindConfigRepo,
+			desired: func(state State) []gocd.ConfigRepo { return state.ConfigRepos },
+			key:     func(repo gocd.ConfigRepo) string { return repo.ID },
+			eTag:    func(repo gocd.ConfigRepo) string { return repo.ETAG },
+			list:    client.GetConfigRepos,
+			get:     client.GetConfigRepo,
+			create:  client.CreateConfigRepo,
+			update: func(repo gocd.ConfigRepo, eTag string) error {
+				repo.ETAG = eTag
+				_, err := client.UpdateConfigRepo(repo)
+
+				return err
+			},
+			remove: client.DeleteConfigRepo,
+		},
+	}
+}
+
+func configID(config gocd.CommonConfig) string {
+	return config.ID
+}
+
+func configETag(config gocd.CommonConfig) string {
+	return config.ETAG
+}
+
+// discard adapts the create functions of the client that return the created object.
+func discard[T any](create func(item T) (T, error)) func(item T) error {
+	return func(item T) error {
+		_, err := create(item)
+
+		return err
+	}
+}
@@ -0,0 +1,97 @@
+package reconcile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the kind of GoCD object managed by the reconciler.
+type Kind string
+
+// Action is the change to be made to an object in GoCD to reach the desired state.
+type Action string
+
+const (
+	KindAuthConfig          Kind = "auth config"
+	KindRole                Kind = "role"
+	KindSecretConfig        Kind = "secret config"
+	KindClusterProfile      Kind = "cluster profile"
+	KindElasticAgentProfile Kind = "elastic agent profile"
+	KindPipelineGroup       Kind = "pipeline group"
+	KindEnvironment         Kind = "environment"
+	KindConfigRepo          Kind = "config repo"
+)
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a single change to be made to an object in GoCD.
+// Current and Desired hold the object of the type the GoCd client works with for the Kind, e.g. gocd.Role;
+// Current is nil for ActionCreate and Desired is nil for ActionDelete.
+type Change struct {
+	Kind    Kind
+	Name    string
+	Action  Action
+	Current any
+	Desired any
+	// ETag is the ETag of the current object, sent while updating it so that changes made to the object
+	// after the plan was computed are not overwritten.
+	ETag string
+}
+
+// Plan holds the changes to reach the desired state, in the order they have to be applied.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether GoCD is already in the desired state.
+func (plan Plan) Empty() bool {
+	return len(plan.Changes) == 0
+}
+
+// String renders the plan in a human-readable form, one change per line followed by the summary.
+func (plan Plan) String() string {
+	if plan.Empty() {
+		return "No changes, GoCD is in the desired state."
+	}
+
+	var builder strings.Builder
+
+	counts := make(map[Action]int)
+
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+
+		builder.WriteString(change.String())
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete]))
+
+	return builder.String()
+}
+
+func (change Change) String() string {
+	symbol := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[change.Action]
+
+	return fmt.Sprintf("%s %s %s '%s'", symbol, change.Action, change.Kind, change.Name)
+}
+
+// ApplyError is returned when a change in the plan could not be applied, the changes before it were applied.
+type ApplyError struct {
+	Change Change
+	Err    error
+}
+
+func (err *ApplyError) Error() string {
+	return fmt.Sprintf("applying change to %s %s '%s' errored with: %v", err.Change.Action, err.Change.Kind, err.Change.Name, err.Err)
+}
+
+// Unwrap returns the error from GoCD, so that helpers like errors.IsPreconditionFailed work on ApplyError.
+func (err *ApplyError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,120 @@
+// Package reconcile brings the GoCD configuration to the desired state declared in a document.
+//
+// The Reconciler reads the current state of pipeline groups, environments, roles, auth configs, cluster profiles,
+// elastic agent profiles, secret configs and config repos through the GoCd client, computes a Plan of changes
+// to reach the desired State and applies it. Objects are created and updated in the order of their dependencies,
+// e.g. the cluster profile before the elastic agent profiles referring to it, and deleted in the reverse order.
+// Updates carry the ETag of the object read while planning, so that the changes made to the object after the plan
+// was computed are not overwritten; such updates fail with 412, see errors.IsPreconditionFailed.
+package reconcile
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// Reconciler computes and applies the changes to bring GoCD to the desired state.
+type Reconciler struct {
+	prune         bool
+	dryRun        bool
+	secretUpdates bool
+	handlers      []handler
+}
+
+// Option configures the Reconciler.
+type Option func(*Reconciler)
+
+// WithPrune deletes the objects present in GoCD but not in the desired state, only for the kinds set in the State.
+// By default, such objects are left as they are.
+func WithPrune() Option {
+	return func(reconciler *Reconciler) {
+		reconciler.prune = true
+	}
+}
+
+// WithDryRun makes Reconcile only compute the plan, without applying it.
+func WithDryRun() Option {
+	return func(reconciler *Reconciler) {
+		reconciler.dryRun = true
+	}
+}
+
+// WithSecretUpdates plans an update of the objects setting the plain text value of a secure property or environment variable,
+// so that rotating a secret is applied. The plain text value could not be compared with the value encrypted by GoCD,
+// hence such objects are updated on every Reconcile. By default, they are taken to be in the desired state.
+func WithSecretUpdates() Option {
+	return func(reconciler *Reconciler) {
+		reconciler.secretUpdates = true
+	}
+}
+
+// New returns the Reconciler that reads and changes the GoCD configuration using the client.
+func New(client gocd.GoCd, opts ...Option) *Reconciler {
+	reconciler := &Reconciler{handlers: handlers(client)}
+
+	for _, opt := range opts {
+		opt(reconciler)
+	}
+
+	return reconciler
+}
+
+// Plan computes the changes to bring GoCD to the desired state, nothing is changed in GoCD.
+// The plain text values of the secure properties and environment variables are taken to match the values encrypted by GoCD,
+// as those could not be decrypted to compare, so a change only in such a value, e.g. rotating a secret, is not planned
+// unless WithSecretUpdates is set.
+func (reconciler *Reconciler) Plan(desired State) (Plan, error) {
+	var plan Plan
+
+	deletes := make([][]Change, 0, len(reconciler.handlers))
+
+	for _, kindHandler := range reconciler.handlers {
+		changes, kindDeletes, err := kindHandler.changes(desired, reconciler.prune, reconciler.secretUpdates)
+		if err != nil {
+			return Plan{}, err
+		}
+
+		plan.Changes = append(plan.Changes, changes...)
+		deletes = append(deletes, kindDeletes)
+	}
+
+	slices.Reverse(deletes)
+
+	for _, kindDeletes := range deletes {
+		plan.Changes = append(plan.Changes, kindDeletes...)
+	}
+
+	return plan, nil
+}
+
+// Apply applies the changes of the plan in order, stopping at the first change that fails with an ApplyError.
+func (reconciler *Reconciler) Apply(plan Plan) error {
+	for _, change := range plan.Changes {
+		index := slices.IndexFunc(reconciler.handlers, func(kindHandler handler) bool {
+			return kindHandler.kind() == change.Kind
+		})
+		if index == -1 {
+			return &ApplyError{Change: change, Err: &errors.GoCDSDKError{Message: fmt.Sprintf("unknown kind '%s'", change.Kind)}}
+		}
+
+		if err := reconciler.handlers[index].apply(change); err != nil {
+			return &ApplyError{Change: change, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// Reconcile computes the plan to bring GoCD to the desired state and applies it, unless WithDryRun is set.
+// The plan is returned in either case, along with the ApplyError when a change could not be applied.
+func (reconciler *Reconciler) Reconcile(desired State) (Plan, error) {
+	plan, err := reconciler.Plan(desired)
+	if err != nil || reconciler.dryRun {
+		return plan, err
+	}
+
+	return plan, reconciler.Apply(plan)
+}
@@ -0,0 +1,258 @@
+package reconcile_test
+
+import (
+	goErrors "errors"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/gocdtest"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/reconcile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var desiredState = []byte(`
+cluster_profiles:
+  - id: docker
+    plugin_id: cd.go.contrib.elastic-agents.docker
+elastic_agent_profiles:
+  - id: ubuntu
+    cluster_profile_id: docker
+roles:
+  - name: admins
+    type: gocd
+    attributes:
+      users: [alice, bob]
+pipeline_groups:
+  - name: first
+    authorization:
+      view:
+        roles: [admins]
+environments: []
+`)
+
+func newServer() *gocdtest.Server {
+	server := gocdtest.NewServer()
+
+	server.AddRoles(
+		gocd.Role{Name: "admins", Type: "gocd", Attributes: gocd.RoleAttribute{Users: []string{"alice"}}},
+		gocd.Role{Name: "viewers", Type: "gocd"},
+	)
+	server.AddPipelineGroups(gocd.PipelineGroup{Name: "first"})
+	server.AddEnvironments(gocd.Environment{Name: "staging"})
+	server.AddConfigRepos(gocd.ConfigRepo{ID: "repo1", PluginID: "yaml.config.plugin"})
+
+	return server
+}
+
+func TestLoadState(t *testing.T) {
+	t.Run("should manage only the kinds set in the desired state", func(t *testing.T) {
+		state, err := reconcile.LoadState(desiredState)
+		require.NoError(t, err)
+
+		assert.Equal(t, []gocd.CommonConfig{{ID: "ubuntu", ClusterProfileID: "docker"}}, state.ElasticAgentProfiles)
+		assert.NotNil(t, state.Environments)
+		assert.Empty(t, state.Environments)
+		assert.Nil(t, state.ConfigRepos)
+	})
+
+	t.Run("should error out when the desired state is not a valid document", func(t *testing.T) {
+		_, err := reconcile.LoadState([]byte("roles: {name"))
+		assert.ErrorContains(t, err, "parsing desired state errored with")
+	})
+}
+
+func TestReconciler_Plan(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	state, err := reconcile.LoadState(desiredState)
+	require.NoError(t, err)
+
+	t.Run("should plan the creates and updates in the order of dependencies without pruning", func(t *testing.T) {
+		plan, err := reconcile.New(server.GoCdClient()).Plan(state)
+		require.NoError(t, err)
+
+		assert.Equal(t, `~ update role 'admins'
++ create cluster profile 'docker'
++ create elastic agent profile 'ubuntu'
+~ update pipeline group 'first'
+Plan: 2 to create, 2 to update, 0 to delete.`, plan.String())
+
+		assert.NotEmpty(t, plan.Changes[0].ETag)
+		assert.Equal(t, gocd.Role{
+			Name: "admins", Type: "gocd", Attributes: gocd.RoleAttribute{Users: []string{"alice"}}, ETAG: plan.Changes[0].ETag,
+		}, plan.Changes[0].Current)
+		assert.Equal(t, gocd.Role{Name: "admins", Type: "gocd", Attributes: gocd.RoleAttribute{Users: []string{"alice", "bob"}}},
+			plan.Changes[0].Desired)
+	})
+
+	t.Run("should plan the deletes of managed kinds in the reverse order of dependencies when pruning", func(t *testing.T) {
+		plan, err := reconcile.New(server.GoCdClient(), reconcile.WithPrune()).Plan(state)
+		require.NoError(t, err)
+
+		assert.Equal(t, `~ update role 'admins'
++ create cluster profile 'docker'
++ create elastic agent profile 'ubuntu'
+~ update pipeline group 'first'
+- delete environment 'staging'
+- delete role 'viewers'
+Plan: 2 to create, 2 to update, 2 to delete.`, plan.String())
+	})
+
+	t.Run("should not plan any change for the secure properties held encrypted by GoCD", func(t *testing.T) {
+		secureServer := gocdtest.NewServer()
+		defer secureServer.Close()
+
+		ldap := gocd.CommonConfig{
+			ID:       "ldap",
+			PluginID: "cd.go.authentication.ldap",
+			Properties: []gocd.PluginConfiguration{
+				{Key: "Url", Value: "ldap://ldap.example.com"},
+				{Key: "Password", Value: "ldap-secret", IsSecure: true},
+			},
+		}
+		secureServer.AddAuthConfigs(ldap)
+
+		plan, err := reconcile.New(secureServer.GoCdClient()).Plan(reconcile.State{AuthConfigs: []gocd.CommonConfig{ldap}})
+		require.NoError(t, err)
+		assert.Equal(t, "No changes, GoCD is in the desired state.", plan.String())
+
+		rotated := ldap
+		rotated.Properties = []gocd.PluginConfiguration{ldap.Properties[0], {Key: "Password", Value: "rotated-secret", IsSecure: true}}
+
+		plan, err = reconcile.New(secureServer.GoCdClient()).Plan(reconcile.State{AuthConfigs: []gocd.CommonConfig{rotated}})
+		require.NoError(t, err)
+		assert.Equal(t, "No changes, GoCD is in the desired state.", plan.String())
+
+		ldap.Properties = []gocd.PluginConfiguration{{Key: "Url", Value: "ldaps://ldap.example.com"}, ldap.Properties[1]}
+
+		plan, err = reconcile.New(secureServer.GoCdClient()).Plan(reconcile.State{AuthConfigs: []gocd.CommonConfig{ldap}})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, gocd.PluginConfiguration{Key: "Password", Value: "ldap-secret", IsSecure: true},
+			plan.Changes[0].Desired.(gocd.CommonConfig).Properties[1])
+	})
+
+	t.Run("should plan the update of the secure properties when the updates of the secrets are enabled", func(t *testing.T) {
+		secureServer := gocdtest.NewServer()
+		defer secureServer.Close()
+
+		ldap := gocd.CommonConfig{
+			ID:         "ldap",
+			PluginID:   "cd.go.authentication.ldap",
+			Properties: []gocd.PluginConfiguration{{Key: "Password", Value: "ldap-secret", IsSecure: true}},
+		}
+		secureServer.AddAuthConfigs(ldap)
+
+		client := secureServer.GoCdClient()
+
+		before, err := client.GetAuthConfig("ldap")
+		require.NoError(t, err)
+
+		ldap.Properties = []gocd.PluginConfiguration{{Key: "Password", Value: "rotated-secret", IsSecure: true}}
+
+		plan, err := reconcile.New(client, reconcile.WithSecretUpdates()).Reconcile(reconcile.State{AuthConfigs: []gocd.CommonConfig{ldap}})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, reconcile.ActionUpdate, plan.Changes[0].Action)
+
+		after, err := client.GetAuthConfig("ldap")
+		require.NoError(t, err)
+		assert.NotEmpty(t, after.Properties[0].EncryptedValue)
+		assert.NotEqual(t, before.Properties[0].EncryptedValue, after.Properties[0].EncryptedValue)
+	})
+
+	t.Run("should error out when an object is defined more than once", func(t *testing.T) {
+		_, err := reconcile.New(server.GoCdClient()).Plan(reconcile.State{Roles: []gocd.Role{{Name: "admins"}, {Name: "admins"}}})
+		assert.EqualError(t, err, "role 'admins' is defined more than once in desired state")
+	})
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	state, err := reconcile.LoadState(desiredState)
+	require.NoError(t, err)
+
+	t.Run("should not change anything in GoCD on dry run", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		plan, err := reconcile.New(server.GoCdClient(), reconcile.WithPrune(), reconcile.WithDryRun()).Reconcile(state)
+		require.NoError(t, err)
+		assert.Len(t, plan.Changes, 6)
+
+		environments, err := server.GoCdClient().GetEnvironments()
+		require.NoError(t, err)
+		assert.Len(t, environments, 1)
+	})
+
+	t.Run("should bring GoCD to the desired state", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		client := server.GoCdClient()
+		reconciler := reconcile.New(client, reconcile.WithPrune())
+
+		_, err := reconciler.Reconcile(state)
+		require.NoError(t, err)
+
+		role, err := client.GetRole("admins")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, role.Attributes.Users)
+
+		group, err := client.GetPipelineGroup("first")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admins"}, group.Authorization.View.Roles)
+
+		profile, err := client.GetElasticAgentProfile("ubuntu")
+		require.NoError(t, err)
+		assert.Equal(t, "docker", profile.ClusterProfileID)
+
+		repos, err := client.GetConfigRepos()
+		require.NoError(t, err)
+		assert.Len(t, repos, 1)
+
+		plan, err := reconciler.Plan(state)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty())
+	})
+
+	t.Run("should delete the elastic agent profile before the cluster profile it refers to", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		server.AddClusterProfiles(gocd.CommonConfig{ID: "docker"})
+		server.AddElasticAgentProfiles(gocd.CommonConfig{ID: "ubuntu", ClusterProfileID: "docker"})
+
+		plan, err := reconcile.New(server.GoCdClient(), reconcile.WithPrune()).
+			Reconcile(reconcile.State{ClusterProfiles: []gocd.CommonConfig{}, ElasticAgentProfiles: []gocd.CommonConfig{}})
+		require.NoError(t, err)
+
+		assert.Equal(t, `- delete elastic agent profile 'ubuntu'
+- delete cluster profile 'docker'
+Plan: 0 to create, 0 to update, 2 to delete.`, plan.String())
+	})
+
+	t.Run("should not overwrite the object changed after the plan was computed", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		client := server.GoCdClient()
+		reconciler := reconcile.New(client)
+
+		plan, err := reconciler.Plan(reconcile.State{Roles: state.Roles})
+		require.NoError(t, err)
+
+		server.AddRoles(gocd.Role{Name: "admins", Type: "gocd", Attributes: gocd.RoleAttribute{Users: []string{"carol"}}})
+
+		err = reconciler.Apply(plan)
+		assert.True(t, errors.IsPreconditionFailed(err))
+
+		var applyErr *reconcile.ApplyError
+
+		require.True(t, goErrors.As(err, &applyErr))
+		assert.Equal(t, "admins", applyErr.Change.Name)
+		assert.Equal(t, reconcile.ActionUpdate, applyErr.Change.Action)
+	})
+}
@@ -0,0 +1,35 @@
+package reconcile
+
+import (
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// State is the desired state of the GoCD configuration.
+//
+// Only the kinds that are set in the State are managed, a kind left out (nil) is neither read nor changed.
+// Objects are matched by name or ID, and only the fields set on the desired object are compared with GoCD,
+// so fields left out are retained as they are in GoCD.
+type State struct {
+	AuthConfigs          []gocd.CommonConfig  `json:"auth_configs,omitempty" yaml:"auth_configs,omitempty"`
+	Roles                []gocd.Role          `json:"roles,omitempty" yaml:"roles,omitempty"`
+	SecretConfigs        []gocd.CommonConfig  `json:"secret_configs,omitempty" yaml:"secret_configs,omitempty"`
+	ClusterProfiles      []gocd.CommonConfig  `json:"cluster_profiles,omitempty" yaml:"cluster_profiles,omitempty"`
+	ElasticAgentProfiles []gocd.CommonConfig  `json:"elastic_agent_profiles,omitempty" yaml:"elastic_agent_profiles,omitempty"`
+	PipelineGroups       []gocd.PipelineGroup `json:"pipeline_groups,omitempty" yaml:"pipeline_groups,omitempty"`
+	Environments         []gocd.Environment   `json:"environments,omitempty" yaml:"environments,omitempty"`
+	ConfigRepos          []gocd.ConfigRepo    `json:"config_repos,omitempty" yaml:"config_repos,omitempty"`
+}
+
+// LoadState parses the desired state from the YAML or JSON document.
+// A kind set to an empty list in the document is managed, and with pruning enabled all its objects are deleted.
+func LoadState(content []byte) (State, error) {
+	var state State
+
+	if err := yaml.Unmarshal(content, &state); err != nil {
+		return State{}, &errors.GoCDError{Message: "parsing desired state errored with", Err: err}
+	}
+
+	return state, nil
+}
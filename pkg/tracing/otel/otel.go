@@ -0,0 +1,75 @@
+// Package otel adapts OpenTelemetry to the tracing of the calls made to GoCD by the SDK.
+//
+// It is a module of its own, so that the SDK does not depend on OpenTelemetry unless it is imported:
+//
+//	client, err := gocd.New(baseURL, gocd.WithHooks(otel.Hooks(otelapi.GetTracerProvider())))
+//
+// The spans are of the kind client, carrying the attributes set by tracing.Hooks.
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer the spans are created by.
+const InstrumentationName = "github.com/nikhilsbhat/gocd-sdk-go/pkg/tracing/otel"
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+type span struct {
+	span trace.Span
+}
+
+// NewTracer returns the tracing.Tracer creating the spans with the tracer of the provider, named InstrumentationName.
+func NewTracer(provider trace.TracerProvider) tracing.Tracer {
+	return &tracer{tracer: provider.Tracer(InstrumentationName)}
+}
+
+// Hooks returns the hooks that trace every attempt of the calls made to GoCD with the tracer of the provider,
+// to be passed to gocd.WithHooks.
+func Hooks(provider trace.TracerProvider) gocd.Hooks {
+	return tracing.Hooks(NewTracer(provider))
+}
+
+func (tracer *tracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+	ctx, otelSpan := tracer.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+
+	return ctx, &span{span: otelSpan}
+}
+
+// SetAttribute sets the attribute on the span, the values of the types not supported by OpenTelemetry are set as strings.
+func (span *span) SetAttribute(key string, value any) {
+	switch value := value.(type) {
+	case string:
+		span.span.SetAttributes(attribute.String(key, value))
+	case int:
+		span.span.SetAttributes(attribute.Int(key, value))
+	case int64:
+		span.span.SetAttributes(attribute.Int64(key, value))
+	case float64:
+		span.span.SetAttributes(attribute.Float64(key, value))
+	case bool:
+		span.span.SetAttributes(attribute.Bool(key, value))
+	default:
+		span.span.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+	}
+}
+
+// SetError records the error on the span and sets its status to error.
+func (span *span) SetError(err error) {
+	span.span.RecordError(err)
+	span.span.SetStatus(codes.Error, err.Error())
+}
+
+func (span *span) End() {
+	span.span.End()
+}
@@ -0,0 +1,71 @@
+package otel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/tracing"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/tracing/otel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == gocd.RolesEndpoint {
+			writer.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, err := gocd.New(server.URL, gocd.WithHooks(otel.Hooks(provider)))
+	require.NoError(t, err)
+
+	t.Run("should export the span of the call named after the method of GoCd", func(t *testing.T) {
+		exporter.Reset()
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GetAgents", spans[0].Name)
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+		assert.Equal(t, otel.InstrumentationName, spans[0].InstrumentationScope.Name)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+		assert.ElementsMatch(t, []attribute.KeyValue{
+			attribute.String(tracing.AttributeMethod, "GetAgents"),
+			attribute.String(tracing.AttributeHTTPMethod, http.MethodGet),
+			attribute.String(tracing.AttributeURLPath, gocd.AgentsEndpoint),
+			attribute.Int(tracing.AttributeStatusCode, http.StatusOK),
+		}, spans[0].Attributes)
+	})
+
+	t.Run("should export the span of the call as failed when GoCD responded with error", func(t *testing.T) {
+		exporter.Reset()
+
+		_, err = client.GetRoles()
+		require.Error(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GetRoles", spans[0].Name)
+		assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "got 403 from GoCD"}, spans[0].Status)
+		require.Len(t, spans[0].Events, 1)
+		assert.Equal(t, "exception", spans[0].Events[0].Name)
+	})
+}
@@ -0,0 +1,89 @@
+// Package tracing traces the calls made to GoCD by the SDK, creating a span per attempt named after the method of GoCd.
+//
+// Hooks returns the gocd.Hooks to be passed to gocd.WithHooks. The spans are created through the Tracer interface,
+// so that the SDK does not depend on OpenTelemetry. The OpenTelemetry adapter is the module pkg/tracing/otel:
+//
+//	client, err := gocd.New(baseURL, gocd.WithHooks(otel.Hooks(otelapi.GetTracerProvider())))
+//
+// The attributes set on the spans follow the semantic conventions of OpenTelemetry for HTTP clients.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+const (
+	AttributeMethod      = "gocd.method"
+	AttributeHTTPMethod  = "http.request.method"
+	AttributeURLPath     = "url.path"
+	AttributeStatusCode  = "http.response.status_code"
+	AttributeResendCount = "http.request.resend_count"
+)
+
+// Tracer starts the spans, see the package pkg/tracing/otel for the one backed by OpenTelemetry.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by the Tracer.
+type Span interface {
+	SetAttribute(key string, value any)
+	// SetError marks the span as failed with the error.
+	SetError(err error)
+	End()
+}
+
+// spanKey is the key of the context holding the span of the attempt.
+type spanKey struct{}
+
+// Hooks returns the hooks that trace every attempt of the calls made to GoCD with the tracer.
+// Responses with the status code 400 and above are marked as failed.
+func Hooks(tracer Tracer) gocd.Hooks {
+	return gocd.Hooks{
+		BeforeRequest: func(ctx context.Context, info gocd.RequestInfo) context.Context {
+			name := info.Method
+			if len(name) == 0 {
+				name = info.HTTPMethod
+			}
+
+			ctx, span := tracer.Start(ctx, name)
+			span.SetAttribute(AttributeMethod, info.Method)
+			span.SetAttribute(AttributeHTTPMethod, info.HTTPMethod)
+			span.SetAttribute(AttributeURLPath, info.Endpoint)
+
+			if info.Attempt > 1 {
+				span.SetAttribute(AttributeResendCount, info.Attempt-1)
+			}
+
+			return context.WithValue(ctx, spanKey{}, span)
+		},
+		AfterResponse: func(ctx context.Context, info gocd.RequestInfo) {
+			span, ok := ctx.Value(spanKey{}).(Span)
+			if !ok {
+				return
+			}
+
+			span.SetAttribute(AttributeStatusCode, info.StatusCode)
+
+			if info.StatusCode >= http.StatusBadRequest {
+				span.SetError(&errors.GoCDSDKError{Message: fmt.Sprintf("got %d from GoCD", info.StatusCode)})
+			}
+
+			span.End()
+		},
+		OnError: func(ctx context.Context, info gocd.RequestInfo) {
+			span, ok := ctx.Value(spanKey{}).(Span)
+			if !ok {
+				return
+			}
+
+			span.SetError(info.Err)
+			span.End()
+		},
+	}
+}
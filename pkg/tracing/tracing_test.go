@@ -0,0 +1,88 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSpan struct {
+	name       string
+	attributes map[string]any
+	err        error
+	ended      bool
+}
+
+func (span *fakeSpan) SetAttribute(key string, value any) {
+	span.attributes[key] = value
+}
+
+func (span *fakeSpan) SetError(err error) {
+	span.err = err
+}
+
+func (span *fakeSpan) End() {
+	span.ended = true
+}
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (tracer *fakeTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+	span := &fakeSpan{name: name, attributes: make(map[string]any)}
+	tracer.spans = append(tracer.spans, span)
+
+	return ctx, span
+}
+
+func TestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == gocd.RolesEndpoint {
+			writer.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+
+	client, err := gocd.New(server.URL, gocd.WithHooks(tracing.Hooks(tracer)))
+	require.NoError(t, err)
+
+	t.Run("should trace the call with the span named after the method of GoCd", func(t *testing.T) {
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+
+		require.Len(t, tracer.spans, 1)
+		assert.Equal(t, &fakeSpan{
+			name: "GetAgents",
+			attributes: map[string]any{
+				tracing.AttributeMethod:     "GetAgents",
+				tracing.AttributeHTTPMethod: http.MethodGet,
+				tracing.AttributeURLPath:    gocd.AgentsEndpoint,
+				tracing.AttributeStatusCode: http.StatusOK,
+			},
+			ended: true,
+		}, tracer.spans[0])
+	})
+
+	t.Run("should mark the span as failed when GoCD responded with error", func(t *testing.T) {
+		_, err = client.GetRoles()
+		require.Error(t, err)
+
+		require.Len(t, tracer.spans, 2)
+		assert.Equal(t, "GetRoles", tracer.spans[1].name)
+		require.EqualError(t, tracer.spans[1].err, "got 403 from GoCD")
+		assert.True(t, tracer.spans[1].ended)
+	})
+}
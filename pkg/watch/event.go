@@ -0,0 +1,37 @@
+package watch
+
+import (
+	"time"
+)
+
+// EventType is the type of change in the state of GoCD.
+type EventType string
+
+const (
+	PipelineStarted         EventType = "pipeline started"
+	PipelinePassed          EventType = "pipeline passed"
+	PipelineFailed          EventType = "pipeline failed"
+	StageCancelled          EventType = "stage cancelled"
+	AgentLostContact        EventType = "agent lost contact"
+	AgentDisabled           EventType = "agent disabled"
+	HealthMessageAdded      EventType = "server health message added"
+	HealthMessageCleared    EventType = "server health message cleared"
+	MaintenanceModeEnabled  EventType = "maintenance mode enabled"
+	MaintenanceModeDisabled EventType = "maintenance mode disabled"
+	ConfigRepoParseErrored  EventType = "config repo parse errored"
+	ConfigRepoParseFixed    EventType = "config repo parse fixed"
+	// PollFailed is emitted by Watcher.Watch when the sources could not be polled, Err holds the error.
+	PollFailed EventType = "poll failed"
+)
+
+// Event is a change in the state of GoCD seen by the Watcher.
+// Name identifies what changed: the pipeline, the stage run as 'pipeline/counter/stage/counter', the agent UUID,
+// the server health message or the config repo ID. It is empty for the changes to the maintenance mode.
+type Event struct {
+	Type    EventType `json:"type" yaml:"type"`
+	Source  Source    `json:"source,omitempty" yaml:"source,omitempty"`
+	Name    string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Message string    `json:"message,omitempty" yaml:"message,omitempty"`
+	Time    time.Time `json:"time" yaml:"time"`
+	Err     error     `json:"-" yaml:"-"`
+}
@@ -0,0 +1,254 @@
+package watch
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+)
+
+const (
+	stageBuilding    = "Building"
+	stageFailing     = "Failing"
+	stagePassed      = "Passed"
+	stageFailed      = "Failed"
+	stageCancelled   = "Cancelled"
+	agentLostContact = "LostContact"
+	agentDisabled    = "Disabled"
+)
+
+// State is the state of GoCD as seen by the Watcher, used to find the changes since the previous poll.
+// A nil field means the source is yet to be polled.
+type State struct {
+	Pipelines      map[string]PipelineState     `json:"pipelines" yaml:"pipelines"`
+	Agents         map[string]AgentState        `json:"agents" yaml:"agents"`
+	HealthMessages map[string]gocd.ServerHealth `json:"health_messages" yaml:"health_messages"`
+	Maintenance    *bool                        `json:"maintenance" yaml:"maintenance"`
+	ConfigRepos    map[string]string            `json:"config_repos" yaml:"config_repos"`
+}
+
+// PipelineState holds the latest run of a pipeline, Stages holds the status of each stage run keyed by 'stage/counter'.
+type PipelineState struct {
+	Counter int               `json:"counter" yaml:"counter"`
+	Status  string            `json:"status" yaml:"status"`
+	Stages  map[string]string `json:"stages" yaml:"stages"`
+}
+
+// AgentState holds the state of an agent.
+type AgentState struct {
+	Name        string `json:"name" yaml:"name"`
+	State       string `json:"state" yaml:"state"`
+	ConfigState string `json:"config_state" yaml:"config_state"`
+}
+
+func (state *State) pipelines(dashboard gocd.Dashboard) []Event {
+	previous, initialised := state.Pipelines, state.Pipelines != nil
+	state.Pipelines = make(map[string]PipelineState)
+	events := make([]Event, 0)
+
+	for _, group := range dashboard.PipelineGroups {
+		for _, pipeline := range group.Pipelines {
+			latest, ok := latestInstance(pipeline.Instances)
+			if !ok {
+				continue
+			}
+
+			current := PipelineState{Counter: latest.Counter, Status: pipelineStatus(latest), Stages: make(map[string]string)}
+			for _, stage := range latest.Stages {
+				current.Stages[stage.Name+"/"+stage.Counter] = stage.Status
+			}
+
+			state.Pipelines[pipeline.Name] = current
+
+			if initialised {
+				events = append(events, pipelineEvents(pipeline.Name, latest, previous[pipeline.Name], current)...)
+			}
+		}
+	}
+
+	return events
+}
+
+func pipelineEvents(name string, latest gocd.DashboardPipelineInstance, previous, current PipelineState) []Event {
+	events := make([]Event, 0)
+
+	if current.Counter > previous.Counter {
+		events = append(events, Event{
+			Type:    PipelineStarted,
+			Name:    name,
+			Message: fmt.Sprintf("%s/%d was triggered by %s", name, current.Counter, latest.TriggeredBy),
+		})
+		previous = PipelineState{}
+	}
+
+	for _, stage := range latest.Stages {
+		key := stage.Name + "/" + stage.Counter
+		if stage.Status == stageCancelled && previous.Stages[key] != stageCancelled {
+			events = append(events, Event{
+				Type:    StageCancelled,
+				Name:    fmt.Sprintf("%s/%d/%s", name, current.Counter, key),
+				Message: fmt.Sprintf("stage %s of %s/%d was cancelled", stage.Name, name, current.Counter),
+			})
+		}
+	}
+
+	if current.Status == previous.Status {
+		return events
+	}
+
+	switch current.Status {
+	case stagePassed:
+		events = append(events, Event{Type: PipelinePassed, Name: name, Message: fmt.Sprintf("%s/%d passed", name, current.Counter)})
+	case stageFailed:
+		events = append(events, Event{Type: PipelineFailed, Name: name, Message: fmt.Sprintf("%s/%d failed", name, current.Counter)})
+	}
+
+	return events
+}
+
+func (state *State) agents(agents []gocd.Agent) []Event {
+	previous, initialised := state.Agents, state.Agents != nil
+	state.Agents = make(map[string]AgentState, len(agents))
+	events := make([]Event, 0)
+
+	for _, agent := range agents {
+		current := AgentState{Name: agent.Name, State: agent.CurrentState, ConfigState: agent.ConfigState}
+		state.Agents[agent.ID] = current
+
+		if !initialised {
+			continue
+		}
+
+		if current.State == agentLostContact && previous[agent.ID].State != agentLostContact {
+			events = append(events, Event{Type: AgentLostContact, Name: agent.ID, Message: fmt.Sprintf("agent %s lost contact", agent.Name)})
+		}
+
+		if current.ConfigState == agentDisabled && previous[agent.ID].ConfigState != agentDisabled {
+			events = append(events, Event{Type: AgentDisabled, Name: agent.ID, Message: fmt.Sprintf("agent %s was disabled", agent.Name)})
+		}
+	}
+
+	return events
+}
+
+func (state *State) serverHealth(messages []gocd.ServerHealth) []Event {
+	previous, initialised := state.HealthMessages, state.HealthMessages != nil
+	state.HealthMessages = make(map[string]gocd.ServerHealth, len(messages))
+	events := make([]Event, 0)
+
+	for _, message := range messages {
+		key := message.Level + ": " + message.Message
+		state.HealthMessages[key] = message
+
+		if _, ok := previous[key]; initialised && !ok {
+			events = append(events, Event{Type: HealthMessageAdded, Name: key, Message: message.Detail})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(previous)) {
+		if _, ok := state.HealthMessages[key]; !ok {
+			events = append(events, Event{Type: HealthMessageCleared, Name: key, Message: previous[key].Detail})
+		}
+	}
+
+	return events
+}
+
+func (state *State) maintenance(maintenance gocd.Maintenance) []Event {
+	previous := state.Maintenance
+	enabled := maintenance.MaintenanceInfo.Enabled
+	state.Maintenance = &enabled
+
+	if previous == nil || *previous == enabled {
+		return nil
+	}
+
+	message := fmt.Sprintf("updated by %s", maintenance.MaintenanceInfo.Metadata.UpdatedBy)
+	if enabled {
+		return []Event{{Type: MaintenanceModeEnabled, Message: message}}
+	}
+
+	return []Event{{Type: MaintenanceModeDisabled, Message: message}}
+}
+
+func (state *State) configRepos(repos []gocd.ConfigRepo) []Event {
+	previous, initialised := state.ConfigRepos, state.ConfigRepos != nil
+	state.ConfigRepos = make(map[string]string, len(repos))
+	events := make([]Event, 0)
+
+	for _, repo := range repos {
+		parseError := repo.ConfigRepoParseInfo.Error
+		state.ConfigRepos[repo.ID] = parseError
+
+		if !initialised || parseError == previous[repo.ID] {
+			continue
+		}
+
+		if len(parseError) != 0 {
+			events = append(events, Event{Type: ConfigRepoParseErrored, Name: repo.ID, Message: parseError})
+
+			continue
+		}
+
+		events = append(events, Event{Type: ConfigRepoParseFixed, Name: repo.ID, Message: fmt.Sprintf("config repo %s was parsed", repo.ID)})
+	}
+
+	return events
+}
+
+func (state State) clone() State {
+	cloned := State{
+		Agents:         maps.Clone(state.Agents),
+		HealthMessages: maps.Clone(state.HealthMessages),
+		ConfigRepos:    maps.Clone(state.ConfigRepos),
+	}
+
+	if state.Maintenance != nil {
+		enabled := *state.Maintenance
+		cloned.Maintenance = &enabled
+	}
+
+	if state.Pipelines != nil {
+		cloned.Pipelines = make(map[string]PipelineState, len(state.Pipelines))
+		for name, pipeline := range state.Pipelines {
+			pipeline.Stages = maps.Clone(pipeline.Stages)
+			cloned.Pipelines[name] = pipeline
+		}
+	}
+
+	return cloned
+}
+
+// latestInstance returns the instance of the pipeline with the highest counter, false when the pipeline never ran.
+func latestInstance(instances []gocd.DashboardPipelineInstance) (gocd.DashboardPipelineInstance, bool) {
+	if len(instances) == 0 {
+		return gocd.DashboardPipelineInstance{}, false
+	}
+
+	return slices.MaxFunc(instances, func(a, b gocd.DashboardPipelineInstance) int {
+		return a.Counter - b.Counter
+	}), true
+}
+
+// pipelineStatus derives the status of the pipeline run from its stages, it is Passed only when all the stages passed.
+// It is empty while the remaining stages are yet to be scheduled, either automatically or on manual approval.
+func pipelineStatus(instance gocd.DashboardPipelineInstance) string {
+	statuses := make([]string, 0, len(instance.Stages))
+	for _, stage := range instance.Stages {
+		statuses = append(statuses, stage.Status)
+	}
+
+	switch {
+	case slices.Contains(statuses, stageBuilding) || slices.Contains(statuses, stageFailing):
+		return stageBuilding
+	case slices.Contains(statuses, stageFailed):
+		return stageFailed
+	case slices.Contains(statuses, stageCancelled):
+		return stageCancelled
+	case len(statuses) != 0 && !slices.ContainsFunc(statuses, func(status string) bool { return status != stagePassed }):
+		return stagePassed
+	default:
+		return ""
+	}
+}
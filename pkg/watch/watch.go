@@ -0,0 +1,205 @@
+// Package watch polls GoCD and streams the changes in its state as typed events.
+//
+// The Watcher polls the configured sources on an interval, compares what it read with the State of the previous poll
+// and emits an Event only for the changes, e.g. a pipeline that started or failed, an agent that lost contact or
+// a server health message that appeared. The first poll of a source only records its state, so watching does not
+// start with a flood of events. The State could be saved and passed back through WithState to resume watching
+// after a restart, emitting the changes that happened meanwhile.
+package watch
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// Source is the part of GoCD polled by the Watcher.
+type Source string
+
+const (
+	// SourcePipelines polls the dashboard for the runs of the pipelines and their stages.
+	SourcePipelines Source = "pipelines"
+	// SourceAgents polls the agents for their state.
+	SourceAgents Source = "agents"
+	// SourceServerHealth polls the server health messages.
+	SourceServerHealth Source = "server health"
+	// SourceMaintenance polls the maintenance mode of the server.
+	SourceMaintenance Source = "maintenance mode"
+	// SourceConfigRepos polls the config repos for the errors parsing them.
+	SourceConfigRepos Source = "config repos"
+)
+
+// defaultInterval is the time waited between the polls when not set through WithInterval.
+const defaultInterval = 30 * time.Second
+
+// Watcher polls GoCD and emits the changes in its state as events.
+type Watcher struct {
+	client   gocd.GoCd
+	interval time.Duration
+	sources  []Source
+	mutex    sync.Mutex
+	state    State
+}
+
+// Option configures the Watcher.
+type Option func(*Watcher)
+
+// WithInterval sets the time waited between the polls, defaults to 30 seconds.
+func WithInterval(interval time.Duration) Option {
+	return func(watcher *Watcher) {
+		watcher.interval = interval
+	}
+}
+
+// WithSources limits the sources polled, all the sources are polled by default.
+func WithSources(sources ...Source) Option {
+	return func(watcher *Watcher) {
+		watcher.sources = sources
+	}
+}
+
+// WithState resumes watching from the state saved earlier, see Watcher.State.
+func WithState(state State) Option {
+	return func(watcher *Watcher) {
+		watcher.state = state.clone()
+	}
+}
+
+// New returns the Watcher that polls GoCD using the client.
+func New(client gocd.GoCd, opts ...Option) *Watcher {
+	watcher := &Watcher{
+		client:   client,
+		interval: defaultInterval,
+		sources:  []Source{SourcePipelines, SourceAgents, SourceServerHealth, SourceMaintenance, SourceConfigRepos},
+	}
+
+	for _, opt := range opts {
+		opt(watcher)
+	}
+
+	return watcher
+}
+
+// State returns the state of GoCD as seen in the latest poll, it could be saved to resume watching later.
+func (watcher *Watcher) State() State {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	return watcher.state.clone()
+}
+
+// Poll polls all the sources once and returns the events for the changes since the previous poll.
+// A source that could not be polled is skipped, keeping its state as is, and the errors are returned along with
+// the events from the other sources.
+func (watcher *Watcher) Poll() ([]Event, error) {
+	return watcher.pollWith(watcher.client)
+}
+
+// pollWith polls all the sources once using the client passed, see Poll.
+func (watcher *Watcher) pollWith(client gocd.GoCd) ([]Event, error) {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	events := make([]Event, 0)
+	errs := make([]error, 0)
+	now := time.Now()
+
+	for _, source := range watcher.sources {
+		sourceEvents, err := watcher.poll(client, source)
+		if err != nil {
+			errs = append(errs, &errors.GoCDError{Message: fmt.Sprintf("polling %s errored with", source), Err: err})
+
+			continue
+		}
+
+		for _, event := range sourceEvents {
+			event.Source = source
+			event.Time = now
+			events = append(events, event)
+		}
+	}
+
+	return events, goErrors.Join(errs...)
+}
+
+// Watch polls GoCD on the interval until the context is done and streams the events on the channel returned.
+// Errors polling are sent as events of type PollFailed, watching continues with the next poll.
+// The calls polling GoCD are bound to the context, so they are cancelled along with it, and the channel is closed.
+func (watcher *Watcher) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+	client := watcher.client.WithContext(ctx)
+
+	go func() {
+		defer close(events)
+
+		for {
+			polled, err := watcher.pollWith(client)
+			if err != nil {
+				polled = append(polled, Event{Type: PollFailed, Message: err.Error(), Time: time.Now(), Err: err})
+			}
+
+			for _, event := range polled {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watcher.interval):
+			}
+		}
+	}()
+
+	return events
+}
+
+func (watcher *Watcher) poll(client gocd.GoCd, source Source) ([]Event, error) {
+	switch source {
+	case SourcePipelines:
+		dashboard, err := client.GetDashboard("")
+		if err != nil {
+			return nil, err
+		}
+
+		return watcher.state.pipelines(dashboard), nil
+	case SourceAgents:
+		agents, err := client.GetAgents()
+		if err != nil {
+			return nil, err
+		}
+
+		return watcher.state.agents(agents), nil
+	case SourceServerHealth:
+		messages, err := client.GetServerHealthMessages()
+		if err != nil {
+			return nil, err
+		}
+
+		return watcher.state.serverHealth(messages), nil
+	case SourceMaintenance:
+		maintenance, err := client.GetMaintenanceModeInfo()
+		if err != nil {
+			return nil, err
+		}
+
+		return watcher.state.maintenance(maintenance), nil
+	case SourceConfigRepos:
+		repos, err := client.GetConfigReposInternal()
+		if err != nil {
+			return nil, err
+		}
+
+		return watcher.state.configRepos(repos), nil
+	default:
+		return nil, &errors.GoCDSDKError{Message: fmt.Sprintf("unknown source '%s'", source)}
+	}
+}
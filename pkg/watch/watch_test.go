@@ -0,0 +1,253 @@
+package watch_test
+
+import (
+	"context"
+	"encoding/json"
+	goErrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/watch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errConnectionRefused = goErrors.New("connection refused")
+
+// fakeClient serves the state of GoCD set in its fields, the rest of the methods of gocd.GoCd are not implemented.
+type fakeClient struct {
+	gocd.GoCd
+	dashboard   gocd.Dashboard
+	agents      []gocd.Agent
+	health      []gocd.ServerHealth
+	maintenance bool
+	repos       []gocd.ConfigRepo
+	err         error
+}
+
+func (client *fakeClient) WithContext(_ context.Context) gocd.GoCd {
+	return client
+}
+
+func (client *fakeClient) GetDashboard(_ string) (gocd.Dashboard, error) {
+	return client.dashboard, client.err
+}
+
+func (client *fakeClient) GetAgents() ([]gocd.Agent, error) {
+	return client.agents, nil
+}
+
+func (client *fakeClient) GetServerHealthMessages() ([]gocd.ServerHealth, error) {
+	return client.health, nil
+}
+
+func (client *fakeClient) GetMaintenanceModeInfo() (gocd.Maintenance, error) {
+	var maintenance gocd.Maintenance
+	maintenance.MaintenanceInfo.Enabled = client.maintenance
+	maintenance.MaintenanceInfo.Metadata.UpdatedBy = "admin"
+
+	return maintenance, nil
+}
+
+func (client *fakeClient) GetConfigReposInternal() ([]gocd.ConfigRepo, error) {
+	return client.repos, nil
+}
+
+func (client *fakeClient) run(counter int, statuses ...string) {
+	stages := make([]gocd.DashboardStage, 0, len(statuses))
+	for index, status := range statuses {
+		stages = append(stages, gocd.DashboardStage{Name: fmt.Sprintf("stage%d", index+1), Counter: "1", Status: status})
+	}
+
+	client.dashboard = gocd.Dashboard{PipelineGroups: []gocd.DashboardPipelineGroup{{
+		Name: "first",
+		Pipelines: []gocd.DashboardPipeline{{
+			Name:      "up42",
+			Instances: []gocd.DashboardPipelineInstance{{Counter: counter, TriggeredBy: "changes", Stages: stages}},
+		}},
+	}}}
+}
+
+func eventTypes(events []watch.Event) []watch.EventType {
+	types := make([]watch.EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+
+	return types
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	t.Run("should emit the events only for the changes since the previous poll", func(t *testing.T) {
+		client := &fakeClient{
+			agents: []gocd.Agent{{ID: "agent-1", Name: "agent-1.example.com", CurrentState: "Idle", ConfigState: "Enabled"}},
+			health: []gocd.ServerHealth{{Level: "WARNING", Message: "Disk space low", Detail: "10% left"}},
+			repos:  []gocd.ConfigRepo{{ID: "repo-1"}},
+		}
+		client.run(1, "Passed", "Passed")
+
+		watcher := watch.New(client)
+
+		events, err := watcher.Poll()
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		client.run(2, "Building", "Unknown")
+		client.agents[0].CurrentState = "LostContact"
+		client.agents[0].ConfigState = "Disabled"
+		client.health = []gocd.ServerHealth{{Level: "ERROR", Message: "Material update failed"}}
+		client.maintenance = true
+		client.repos[0].ConfigRepoParseInfo.Error = "invalid yaml"
+
+		events, err = watcher.Poll()
+		require.NoError(t, err)
+		assert.Equal(t, []watch.EventType{
+			watch.PipelineStarted,
+			watch.AgentLostContact,
+			watch.AgentDisabled,
+			watch.HealthMessageAdded,
+			watch.HealthMessageCleared,
+			watch.MaintenanceModeEnabled,
+			watch.ConfigRepoParseErrored,
+		}, eventTypes(events))
+		assert.Equal(t, "up42/2 was triggered by changes", events[0].Message)
+		assert.Equal(t, watch.SourcePipelines, events[0].Source)
+		assert.Equal(t, "ERROR: Material update failed", events[3].Name)
+		assert.Equal(t, "invalid yaml", events[6].Message)
+
+		events, err = watcher.Poll()
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		client.run(2, "Failed", "Unknown")
+		client.maintenance = false
+		client.repos[0].ConfigRepoParseInfo.Error = ""
+
+		events, err = watcher.Poll()
+		require.NoError(t, err)
+		assert.Equal(t, []watch.EventType{watch.PipelineFailed, watch.MaintenanceModeDisabled, watch.ConfigRepoParseFixed}, eventTypes(events))
+	})
+
+	t.Run("should emit the stage cancelled and the pipeline passed even when the run started and completed between polls", func(t *testing.T) {
+		client := &fakeClient{}
+		client.run(1, "Passed")
+
+		watcher := watch.New(client, watch.WithSources(watch.SourcePipelines))
+		_, err := watcher.Poll()
+		require.NoError(t, err)
+
+		client.run(2, "Cancelled")
+
+		events, err := watcher.Poll()
+		require.NoError(t, err)
+		assert.Equal(t, []watch.EventType{watch.PipelineStarted, watch.StageCancelled}, eventTypes(events))
+		assert.Equal(t, "up42/2/stage1/1", events[1].Name)
+
+		client.run(3, "Passed", "Passed")
+
+		events, err = watcher.Poll()
+		require.NoError(t, err)
+		assert.Equal(t, []watch.EventType{watch.PipelineStarted, watch.PipelinePassed}, eventTypes(events))
+	})
+
+	t.Run("should resume from the state saved emitting the changes made meanwhile", func(t *testing.T) {
+		client := &fakeClient{}
+		client.run(1, "Passed")
+
+		watcher := watch.New(client, watch.WithSources(watch.SourcePipelines))
+		_, err := watcher.Poll()
+		require.NoError(t, err)
+
+		saved, err := json.Marshal(watcher.State())
+		require.NoError(t, err)
+
+		var state watch.State
+		require.NoError(t, json.Unmarshal(saved, &state))
+
+		client.run(2, "Failed")
+
+		events, err := watch.New(client, watch.WithSources(watch.SourcePipelines), watch.WithState(state)).Poll()
+		require.NoError(t, err)
+		assert.Equal(t, []watch.EventType{watch.PipelineStarted, watch.PipelineFailed}, eventTypes(events))
+	})
+
+	t.Run("should keep polling the other sources when one of them errored", func(t *testing.T) {
+		client := &fakeClient{err: errConnectionRefused}
+
+		watcher := watch.New(client, watch.WithSources(watch.SourcePipelines, watch.SourceMaintenance))
+
+		_, err := watcher.Poll()
+		require.EqualError(t, err, "polling pipelines errored with connection refused")
+		assert.Nil(t, watcher.State().Pipelines)
+		assert.NotNil(t, watcher.State().Maintenance)
+	})
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	t.Run("should stream the events until the context is done", func(t *testing.T) {
+		client := &fakeClient{}
+		client.run(1, "Passed")
+
+		watcher := watch.New(client, watch.WithSources(watch.SourcePipelines), watch.WithInterval(time.Millisecond))
+		_, err := watcher.Poll()
+		require.NoError(t, err)
+
+		client.err = errConnectionRefused
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := watcher.Watch(ctx)
+
+		event := <-events
+		assert.Equal(t, watch.PollFailed, event.Type)
+		require.EqualError(t, event.Err, "polling pipelines errored with connection refused")
+
+		cancel()
+
+		for event = range events {
+			assert.Equal(t, watch.PollFailed, event.Type)
+		}
+	})
+
+	t.Run("should cancel the call polling GoCD when the context is done", func(t *testing.T) {
+		polling := make(chan struct{})
+
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+			close(polling)
+
+			select {
+			case <-req.Context().Done():
+			case <-time.After(time.Minute):
+			}
+		}))
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(gocd.RetryPolicy{}))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := watch.New(client, watch.WithSources(watch.SourcePipelines)).Watch(ctx)
+
+		<-polling
+		cancel()
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for event := range events {
+				assert.Equal(t, watch.PollFailed, event.Type)
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("watching did not stop while the call polling GoCD was in flight")
+		}
+	})
+}
@@ -8,19 +8,13 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetPluginsInfo() (PluginsInfo, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return PluginsInfo{}, err
-	}
-
 	var pluginInfosCfg PluginsInfos
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionSeven,
 		}).
@@ -48,14 +42,9 @@ func (conf *client) GetPluginsInfo() (PluginsInfo, error) {
 }
 
 func (conf *client) GetPluginInfo(name string) (Plugin, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Plugin{}, err
-	}
-
 	var pluginInfoCfg *Plugin
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionSeven,
 		}).
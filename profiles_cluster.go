@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetClusterProfiles() (ProfilesConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return ProfilesConfig{}, err
-	}
-
 	var profilesCfg ProfilesConfigs
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -41,14 +35,9 @@ func (conf *client) GetClusterProfiles() (ProfilesConfig, error) {
 }
 
 func (conf *client) GetClusterProfile(name string) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var profilesCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -71,14 +60,9 @@ func (conf *client) GetClusterProfile(name string) (CommonConfig, error) {
 }
 
 func (conf *client) CreateClusterProfile(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var profileCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -103,14 +87,9 @@ func (conf *client) CreateClusterProfile(config CommonConfig) (CommonConfig, err
 }
 
 func (conf *client) UpdateClusterProfile(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var storeCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -136,12 +115,7 @@ func (conf *client) UpdateClusterProfile(config CommonConfig) (CommonConfig, err
 }
 
 func (conf *client) DeleteClusterProfile(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
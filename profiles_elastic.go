@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetElasticAgentProfiles() (ProfilesConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return ProfilesConfig{}, err
-	}
-
 	var elasticAgentCfg ProfilesConfigs
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -41,14 +35,9 @@ func (conf *client) GetElasticAgentProfiles() (ProfilesConfig, error) {
 }
 
 func (conf *client) GetElasticAgentProfile(name string) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var elasticAgentCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -71,14 +60,9 @@ func (conf *client) GetElasticAgentProfile(name string) (CommonConfig, error) {
 }
 
 func (conf *client) CreateElasticAgentProfile(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var elasticAgentCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -103,14 +87,9 @@ func (conf *client) CreateElasticAgentProfile(config CommonConfig) (CommonConfig
 }
 
 func (conf *client) UpdateElasticAgentProfile(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var elasticAgentCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionTwo,
 			"Content-Type": ContentJSON,
@@ -136,12 +115,7 @@ func (conf *client) UpdateElasticAgentProfile(config CommonConfig) (CommonConfig
 }
 
 func (conf *client) DeleteElasticAgentProfile(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionTwo,
 		}).
@@ -158,14 +132,9 @@ func (conf *client) DeleteElasticAgentProfile(name string) error {
 }
 
 func (conf *client) GetElasticAgentProfileUsage(profileID string) ([]ElasticProfileUsage, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var elasticProfileUsage []ElasticProfileUsage
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
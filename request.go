@@ -0,0 +1,119 @@
+package gocd
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestOption overrides the configuration of the client for the calls made through the client returned by WithRequestOptions.
+type RequestOption func(opts *requestOptions)
+
+// requestOptions holds the overrides set using RequestOption.
+type requestOptions struct {
+	headers    map[string]string
+	maxRetries *int
+	timeout    time.Duration
+}
+
+// requestTimeoutKey is the key of the context holding the timeout of each attempt of the call, set by WithRequestTimeout.
+type requestTimeoutKey struct{}
+
+// requestMaxRetriesKey is the key of the context holding the retries of the call, set by WithMaxRetries.
+type requestMaxRetriesKey struct{}
+
+// WithHeader sets the header on every call made, the headers set by the methods themselves, like Accept, take precedence.
+func WithHeader(key, value string) RequestOption {
+	return func(opts *requestOptions) {
+		if opts.headers == nil {
+			opts.headers = make(map[string]string)
+		}
+
+		opts.headers[key] = value
+	}
+}
+
+// WithMaxRetries caps the number of times a failed call is retried, which cannot go beyond the one set on the client.
+// Pass 0 to disable the retries, e.g. for the calls which are to fail fast.
+func WithMaxRetries(count int) RequestOption {
+	return func(opts *requestOptions) {
+		opts.maxRetries = &count
+	}
+}
+
+// WithRequestTimeout sets the timeout of each attempt of the calls made, the one set on the client by WithTimeout
+// still applies, hence it could only be shortened.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(opts *requestOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithRequestOptions returns a copy of the client making every call with the options passed, on top of the ones set on
+// the client it is called on. The underlying http client is shared with the original client, which is left untouched.
+func (conf *client) WithRequestOptions(opts ...RequestOption) GoCd {
+	newClient := *conf
+	newClient.requestOpts.headers = maps.Clone(conf.requestOpts.headers)
+
+	for _, opt := range opts {
+		opt(&newClient.requestOpts)
+	}
+
+	return &newClient
+}
+
+// request returns the request to make a call to GoCD with, bound to the context and carrying the request options of the client.
+func (conf *client) request() *resty.Request {
+	ctx := conf.getContext()
+
+	if conf.requestOpts.timeout != 0 {
+		ctx = context.WithValue(ctx, requestTimeoutKey{}, conf.requestOpts.timeout)
+	}
+
+	if conf.requestOpts.maxRetries != nil {
+		ctx = context.WithValue(ctx, requestMaxRetriesKey{}, *conf.requestOpts.maxRetries)
+	}
+
+	return conf.httpClient.R().
+		SetContext(ctx).
+		SetHeaders(conf.requestOpts.headers)
+}
+
+// setRequestTimeout wraps the transport of the resty client to apply the timeout set by WithRequestTimeout.
+func setRequestTimeout(httpClient *resty.Client) {
+	next := httpClient.GetClient().Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	httpClient.SetTransport(&timeoutTransport{next: next})
+}
+
+// timeoutTransport cancels the round trip made by the transport wrapped once the timeout of the call passes,
+// while the response is still being read.
+type timeoutTransport struct {
+	next http.RoundTripper
+}
+
+func (transport *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration)
+	if !ok {
+		return transport.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+
+	resp, err := transport.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: cancel}
+
+	return resp, nil
+}
@@ -0,0 +1,112 @@
+package gocd_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithRequestOptions(t *testing.T) {
+	t.Run("should set the headers only on the calls made through the client returned", func(t *testing.T) {
+		headers := make(chan string, 2)
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			headers <- req.Header.Get("X-Request-Id")
+
+			_, _ = writer.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client, err := gocd.New(server.URL)
+		require.NoError(t, err)
+
+		_, err = client.WithRequestOptions(gocd.WithHeader("X-Request-Id", "42")).GetAgents()
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+
+		assert.Equal(t, "42", <-headers)
+		assert.Empty(t, <-headers)
+	})
+
+	t.Run("should cap the retries of the calls made through the client returned", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := flakyServer(5, http.StatusServiceUnavailable, "", &calls)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(gocd.RetryPolicy{
+			MaxRetries:      3,
+			InitialInterval: time.Millisecond,
+			RetryOnStatus:   gocd.TransientStatusCodes,
+		}))
+		require.NoError(t, err)
+
+		_, err = client.WithRequestOptions(gocd.WithMaxRetries(1)).GetAgents()
+		require.Error(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+
+		calls.Store(0)
+
+		_, err = client.GetAgents()
+		require.Error(t, err)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+
+	t.Run("should time out the calls made through the client returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+
+			_, _ = writer.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetry(0, 0))
+		require.NoError(t, err)
+
+		_, err = client.WithRequestOptions(gocd.WithRequestTimeout(50 * time.Millisecond)).GetAgents()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+	})
+
+	t.Run("should be safe to make the calls concurrently", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = writer.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client, err := gocd.New(server.URL)
+		require.NoError(t, err)
+
+		var waitGroup sync.WaitGroup
+
+		for index := range 10 {
+			waitGroup.Add(1)
+
+			go func() {
+				defer waitGroup.Done()
+
+				caller := client
+				if index%2 == 0 {
+					caller = client.WithRequestOptions(gocd.WithHeader("X-Index", "even"), gocd.WithMaxRetries(0))
+				}
+
+				_, agentsErr := caller.GetAgents()
+				assert.NoError(t, agentsErr)
+			}()
+		}
+
+		waitGroup.Wait()
+	})
+}
@@ -0,0 +1,173 @@
+package gocd
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// RetryPolicy decides which of the failed calls made to GoCD are retried and how long to wait between the retries.
+// Calls that GoCD could not be reached for are always considered for a retry, the ones GoCD responded to only when
+// the status code is one of RetryOnStatus.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a failed call is retried, 0 disables the retries.
+	MaxRetries int
+	// InitialInterval is the time to wait before the first retry, the wait is never shorter than it.
+	InitialInterval time.Duration
+	// Multiplier is the factor by which the wait grows on every retry, values less than 1 keep the wait constant.
+	Multiplier float64
+	// MaxInterval caps the wait grown by the Multiplier, 0 leaves it uncapped.
+	// The Retry-After sent by GoCD is honoured even when it is longer.
+	MaxInterval time.Duration
+	// Jitter is the fraction, between 0 and 1, by which the wait is randomly increased, so that the clients
+	// which failed together do not retry together.
+	Jitter float64
+	// MaxElapsedTime is the time after the first attempt beyond which no retry is made, 0 leaves it unlimited.
+	MaxElapsedTime time.Duration
+	// RetryOnStatus are the status codes of the responses to be retried, see TransientStatusCodes. It is empty by default,
+	// so retrying the responses of GoCD is opt-in.
+	RetryOnStatus []int
+	// RetryNonIdempotent enables retrying the POST and PATCH calls, e.g. SchedulePipeline or CreatePipeline,
+	// which are not retried by default as GoCD might have acted upon the failed attempt already.
+	// AppendJobArtifact is never retried, though it is a PUT call.
+	RetryNonIdempotent bool
+}
+
+// TransientStatusCodes are the status codes returned by GoCD, or the proxies in front of it, when it is temporarily
+// unable to serve the call. Set them as RetryOnStatus of the RetryPolicy to retry such calls.
+var TransientStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryStartKey is the key of the context holding the time at which the first attempt of the call was made.
+type retryStartKey struct{}
+
+// DefaultRetryPolicy returns the RetryPolicy used by the client when none is set, which retries the idempotent calls
+// that GoCD could not be reached for 5 times, waiting 5 seconds between them. It neither backs off nor adds jitter,
+// and retries none of the responses of GoCD as RetryOnStatus is empty.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:      defaultRetryCount,
+		InitialInterval: defaultRetryWaitTime * time.Second,
+		Multiplier:      1,
+	}
+}
+
+// WithRetryPolicy sets the policy by which the failed calls are retried, this overrides WithRetry set before it.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(opts *options) error {
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return &errors.GoCDSDKError{Message: "jitter of the retry policy should be between 0 and 1"}
+		}
+
+		opts.retryPolicy = policy
+
+		return nil
+	}
+}
+
+// setRetryPolicy makes the resty client retry the calls as per the policy. The number of retries and the initial
+// interval are kept on the resty client, so that they could still be changed by SetRetryCount and SetRetryWaitTime.
+func setRetryPolicy(httpClient *resty.Client, policy RetryPolicy) {
+	httpClient.SetRetryCount(policy.MaxRetries)
+	httpClient.SetRetryWaitTime(policy.InitialInterval)
+	// the wait is computed by the policy, resty would otherwise cap it to its own maximum.
+	httpClient.SetRetryMaxWaitTime(math.MaxInt64)
+
+	httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if req.Attempt <= 1 {
+			req.SetContext(context.WithValue(req.Context(), retryStartKey{}, time.Now()))
+		}
+
+		return nil
+	})
+
+	httpClient.AddRetryCondition(func(resp *resty.Response, err error) bool {
+		return policy.shouldRetry(httpClient.RetryWaitTime, resp, err)
+	})
+
+	httpClient.SetRetryAfter(func(restyClient *resty.Client, resp *resty.Response) (time.Duration, error) {
+		return policy.wait(restyClient.RetryWaitTime, resp, rand.Float64()), nil //nolint:gosec
+	})
+}
+
+// shouldRetry reports whether the failed attempt is to be retried, it is not when the longest wait before
+// the retry would go beyond MaxElapsedTime.
+func (policy RetryPolicy) shouldRetry(initial time.Duration, resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+
+	if !policy.RetryNonIdempotent && !isIdempotent(resp.Request.Method) {
+		return false
+	}
+
+	if maxRetries, ok := resp.Request.Context().Value(requestMaxRetriesKey{}).(int); ok && resp.Request.Attempt > maxRetries {
+		return false
+	}
+
+	if err == nil && !slices.Contains(policy.RetryOnStatus, resp.StatusCode()) {
+		return false
+	}
+
+	if policy.MaxElapsedTime == 0 {
+		return true
+	}
+
+	start, ok := resp.Request.Context().Value(retryStartKey{}).(time.Time)
+	if !ok {
+		return true
+	}
+
+	return time.Since(start)+policy.wait(initial, resp, 1) <= policy.MaxElapsedTime
+}
+
+// wait returns the time to wait before the next attempt, the Retry-After sent by GoCD takes precedence over the backoff.
+// The random passed, between 0 and 1, decides the share of the Jitter added.
+func (policy RetryPolicy) wait(initial time.Duration, resp *resty.Response, random float64) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return max(wait, initial)
+	}
+
+	wait := float64(initial) * math.Pow(max(policy.Multiplier, 1), float64(max(resp.Request.Attempt-1, 0)))
+	wait *= 1 + policy.Jitter*random
+
+	if policy.MaxInterval != 0 {
+		wait = math.Min(wait, float64(policy.MaxInterval))
+	}
+
+	return max(time.Duration(wait), initial)
+}
+
+// retryAfter parses the Retry-After header of the response, which is either the seconds to wait or an HTTP date.
+func retryAfter(resp *resty.Response) (time.Duration, bool) {
+	value := resp.Header().Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether making the call again has the same effect on GoCD as making it once.
+func isIdempotent(method string) bool {
+	return method != http.MethodPost && method != http.MethodPatch
+}
@@ -0,0 +1,135 @@
+package gocd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer responds with the status code and the Retry-After passed for the first failures calls, and with 200 after.
+func flakyServer(failures int32, statusCode int, retryAfter string, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= failures {
+			if len(retryAfter) != 0 {
+				writer.Header().Set("Retry-After", retryAfter)
+			}
+
+			writer.WriteHeader(statusCode)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{}`))
+	}))
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	policy := gocd.RetryPolicy{
+		MaxRetries:      3,
+		InitialInterval: time.Millisecond,
+		Multiplier:      2,
+		Jitter:          0.5,
+		RetryOnStatus:   gocd.TransientStatusCodes,
+	}
+
+	t.Run("should retry the calls GoCD responded to with the transient status codes", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := flakyServer(2, http.StatusServiceUnavailable, "", &calls)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("should not retry the calls GoCD responded to with the status codes not set in the policy", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := flakyServer(1, http.StatusInternalServerError, "", &calls)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.EqualError(t, err, "got 500 from GoCD while making GET call for "+server.URL+gocd.AgentsEndpoint+"\nwith BODY:")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should not retry the non idempotent calls unless enabled in the policy", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := flakyServer(1, http.StatusBadGateway, "", &calls)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		err = client.SchedulePipeline("sample", gocd.Schedule{})
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+
+		nonIdempotentPolicy := policy
+		nonIdempotentPolicy.RetryNonIdempotent = true
+
+		client, err = gocd.New(server.URL, gocd.WithRetryPolicy(nonIdempotentPolicy))
+		require.NoError(t, err)
+
+		calls.Store(0)
+
+		// the retry is answered with 200 while scheduling expects 202, which tells that the call was retried.
+		err = client.SchedulePipeline("sample", gocd.Schedule{})
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "got 502")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should wait for the time set in Retry-After before retrying", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := flakyServer(1, http.StatusTooManyRequests, "1", &calls)
+		defer server.Close()
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		_, err = client.GetAgents()
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should not retry when the wait would go beyond the max elapsed time", func(t *testing.T) {
+		var calls atomic.Int32
+
+		server := flakyServer(1, http.StatusServiceUnavailable, "120", &calls)
+		defer server.Close()
+
+		elapsedPolicy := policy
+		elapsedPolicy.MaxElapsedTime = time.Minute
+
+		client, err := gocd.New(server.URL, gocd.WithRetryPolicy(elapsedPolicy))
+		require.NoError(t, err)
+
+		_, err = client.GetAgents()
+		require.EqualError(t, err, "got 503 from GoCD while making GET call for "+server.URL+gocd.AgentsEndpoint+"\nwith BODY:")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should error out when the jitter is not between 0 and 1", func(t *testing.T) {
+		_, err := gocd.New("http://localhost:8156/go", gocd.WithRetryPolicy(gocd.RetryPolicy{Jitter: 2}))
+		require.EqualError(t, err, "jitter of the retry policy should be between 0 and 1")
+	})
+}
@@ -7,19 +7,13 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetRoles() (RolesConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return RolesConfig{}, err
-	}
-
 	var rolesCfg RolesConfigs
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -42,14 +36,9 @@ func (conf *client) GetRoles() (RolesConfig, error) {
 }
 
 func (conf *client) GetRole(name string) (Role, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Role{}, err
-	}
-
 	var roleCfg Role
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -72,14 +61,9 @@ func (conf *client) GetRole(name string) (Role, error) {
 }
 
 func (conf *client) GetRolesByType(roleType string) (RolesConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return RolesConfig{}, err
-	}
-
 	var roleCfg RolesConfigs
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -103,14 +87,9 @@ func (conf *client) GetRolesByType(roleType string) (RolesConfig, error) {
 }
 
 func (conf *client) CreateRole(config Role) (Role, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Role{}, err
-	}
-
 	var roleCfg Role
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -135,14 +114,9 @@ func (conf *client) CreateRole(config Role) (Role, error) {
 }
 
 func (conf *client) UpdateRole(config Role) (Role, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return Role{}, err
-	}
-
 	var roleCfg Role
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -168,12 +142,7 @@ func (conf *client) UpdateRole(config Role) (Role, error) {
 }
 
 func (conf *client) DeleteRole(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
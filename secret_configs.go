@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetSecretConfigs() (SecretsConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return SecretsConfig{}, err
-	}
-
 	var secretsCfg SecretsConfigs
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -41,14 +35,9 @@ func (conf *client) GetSecretConfigs() (SecretsConfig, error) {
 }
 
 func (conf *client) GetSecretConfig(name string) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var secretCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -71,14 +60,9 @@ func (conf *client) GetSecretConfig(name string) (CommonConfig, error) {
 }
 
 func (conf *client) CreateSecretConfig(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var secretsCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -103,14 +87,9 @@ func (conf *client) CreateSecretConfig(config CommonConfig) (CommonConfig, error
 }
 
 func (conf *client) UpdateSecretConfig(config CommonConfig) (CommonConfig, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return CommonConfig{}, err
-	}
-
 	var secretsCfg CommonConfig
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -136,12 +115,7 @@ func (conf *client) UpdateSecretConfig(config CommonConfig) (CommonConfig, error
 }
 
 func (conf *client) DeleteSecretConfig(name string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
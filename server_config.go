@@ -0,0 +1,614 @@
+package gocd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// ImportOption customises the import of the ServerConfigBundle done by ImportServerConfig.
+type ImportOption func(opts *importOptions)
+
+type importOptions struct {
+	secureValues map[string]string
+}
+
+// WithSecureValues supplies the plain text of the secure values dropped from the bundle by ExportServerConfig, keyed by
+// their paths listed under SecureValues of the bundle, e.g. 'auth_configs/ldap/Password' or 'environments/prod/DB_PASS'.
+// GoCD importing the bundle encrypts them with its own cipher key.
+func WithSecureValues(values map[string]string) ImportOption {
+	return func(opts *importOptions) {
+		maps.Copy(opts.secureValues, values)
+	}
+}
+
+// configImporter imports objects of a kind, creating the ones that do not exist in GoCD
+// and updating the ones that exist using their current ETag.
+type configImporter[T any] struct {
+	kind   string
+	key    func(object T) string
+	get    func(name string) (T, error)
+	create func(object T) error
+	update func(object, current T) error
+}
+
+func (importer configImporter[T]) run(objects []T) error {
+	for _, object := range objects {
+		current, err := importer.get(importer.key(object))
+
+		switch {
+		case err == nil:
+			err = importer.update(object, current)
+		case errors.IsNotFound(err):
+			err = importer.create(object)
+		}
+
+		if err != nil {
+			return &errors.GoCDError{Message: fmt.Sprintf("importing %s '%s' errored with", importer.kind, importer.key(object)), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// ExportServerConfig exports the configuration of GoCD server, that is not part of pipelines, to a ServerConfigBundle.
+// The bundle could be marshalled to JSON or YAML and imported to another GoCD server using ImportServerConfig.
+// Environments defined only in config repos are left out, and environments are exported without their pipelines
+// as the pipelines are not part of the bundle. Packages of the package repositories are not part of the bundle either.
+//
+// The values that GoCD encrypts, like the secure properties of the plugins, secure environment variables and
+// the password of the mail server, are dropped from the bundle as only the GoCD server exporting them could decrypt them.
+// Their paths are listed under SecureValues of the bundle, and their plain text is to be supplied using WithSecureValues
+// while importing the bundle.
+func (conf *client) ExportServerConfig() (ServerConfigBundle, error) {
+	bundle := ServerConfigBundle{Version: ServerConfigBundleVersion}
+
+	exportError := func(name string, err error) error {
+		return &errors.GoCDError{Message: fmt.Sprintf("exporting %s errored with", name), Err: err}
+	}
+
+	siteURL, err := conf.GetSiteURL()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("site url", err)
+	}
+
+	bundle.SiteURL = siteURL
+
+	mailServer, err := conf.GetMailServerConfig()
+	if err != nil && !errors.IsNotFound(err) {
+		return ServerConfigBundle{}, exportError("mail server config", err)
+	}
+
+	if err == nil {
+		bundle.MailServer = &mailServer
+	}
+
+	if bundle.ArtifactConfig, err = conf.GetArtifactConfig(); err != nil {
+		return ServerConfigBundle{}, exportError("artifact config", err)
+	}
+
+	backup, err := conf.GetBackupConfig()
+	if err != nil && !errors.IsNotFound(err) {
+		return ServerConfigBundle{}, exportError("backup config", err)
+	}
+
+	if err == nil {
+		bundle.Backup = &backup
+	}
+
+	timeout, err := conf.GetDefaultJobTimeout()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("default job timeout", err)
+	}
+
+	if bundle.DefaultJobTimeout, err = strconv.Atoi(timeout["default_job_timeout"]); err != nil {
+		return ServerConfigBundle{}, exportError("default job timeout", err)
+	}
+
+	if bundle.SystemAdmins, err = conf.GetSystemAdmins(); err != nil {
+		return ServerConfigBundle{}, exportError("system admins", err)
+	}
+
+	if bundle.AuthConfigs, err = conf.GetAuthConfigs(); err != nil {
+		return ServerConfigBundle{}, exportError("auth configs", err)
+	}
+
+	roles, err := conf.GetRoles()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("roles", err)
+	}
+
+	bundle.Roles = roles.Role
+
+	if bundle.PluginSettings, err = conf.exportPluginSettings(); err != nil {
+		return ServerConfigBundle{}, exportError("plugin settings", err)
+	}
+
+	secretConfigs, err := conf.GetSecretConfigs()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("secret configs", err)
+	}
+
+	bundle.SecretConfigs = secretConfigs.CommonConfigs
+
+	clusterProfiles, err := conf.GetClusterProfiles()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("cluster profiles", err)
+	}
+
+	bundle.ClusterProfiles = append(clusterProfiles.CommonConfigs, clusterProfiles.ClusterProfilesConfig...)
+
+	elasticProfiles, err := conf.GetElasticAgentProfiles()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("elastic agent profiles", err)
+	}
+
+	bundle.ElasticAgentProfiles = elasticProfiles.CommonConfigs
+
+	artifactStores, err := conf.GetArtifactStores()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("artifact stores", err)
+	}
+
+	bundle.ArtifactStores = artifactStores.CommonConfigs
+
+	if bundle.PackageRepositories, err = conf.GetPackageRepositories(); err != nil {
+		return ServerConfigBundle{}, exportError("package repositories", err)
+	}
+
+	environments, err := conf.GetEnvironments()
+	if err != nil {
+		return ServerConfigBundle{}, exportError("environments", err)
+	}
+
+	for _, environment := range environments {
+		if environment.definedInConfigRepo() {
+			continue
+		}
+
+		bundle.Environments = append(bundle.Environments, Environment{Name: environment.Name, EnvVars: environment.EnvVars})
+	}
+
+	if bundle.ConfigRepos, err = conf.GetConfigRepos(); err != nil {
+		return ServerConfigBundle{}, exportError("config repos", err)
+	}
+
+	bundle.clean()
+	bundle.dropSecureValues()
+
+	return bundle, nil
+}
+
+// ImportServerConfig imports the ServerConfigBundle exported using ExportServerConfig to GoCD.
+// Objects that do not exist in GoCD are created and the existing ones are updated, nothing is deleted.
+// System admins are imported at the end, so that the user importing the bundle remains admin until then.
+// The plain text of all the secure values listed in the bundle must be supplied using WithSecureValues, and
+// the import errors out before making any change otherwise.
+func (conf *client) ImportServerConfig(bundle ServerConfigBundle, opts ...ImportOption) error {
+	if bundle.Version != ServerConfigBundleVersion {
+		return &errors.GoCDSDKError{
+			Message: fmt.Sprintf("server config bundle of version %d is not supported, supported version is %d", bundle.Version, ServerConfigBundleVersion),
+		}
+	}
+
+	importOpts := importOptions{secureValues: make(map[string]string)}
+	for _, opt := range opts {
+		opt(&importOpts)
+	}
+
+	if err := bundle.supplySecureValues(importOpts.secureValues); err != nil {
+		return err
+	}
+
+	importError := func(name string, err error) error {
+		return &errors.GoCDError{Message: fmt.Sprintf("importing %s errored with", name), Err: err}
+	}
+
+	if len(bundle.SiteURL.SiteURL) != 0 || len(bundle.SiteURL.SecureSiteURL) != 0 {
+		if _, err := conf.CreateOrUpdateSiteURL(bundle.SiteURL); err != nil {
+			return importError("site url", err)
+		}
+	}
+
+	if bundle.MailServer != nil {
+		if _, err := conf.CreateOrUpdateMailServerConfig(*bundle.MailServer); err != nil {
+			return importError("mail server config", err)
+		}
+	}
+
+	if len(bundle.ArtifactConfig.ArtifactsDir) != 0 {
+		current, err := conf.GetArtifactConfig()
+		if err != nil {
+			return importError("artifact config", err)
+		}
+
+		bundle.ArtifactConfig.ETAG = current.ETAG
+
+		if _, err = conf.UpdateArtifactConfig(bundle.ArtifactConfig); err != nil {
+			return importError("artifact config", err)
+		}
+	}
+
+	if bundle.Backup != nil {
+		if err := conf.CreateOrUpdateBackupConfig(*bundle.Backup); err != nil {
+			return importError("backup config", err)
+		}
+	}
+
+	if err := conf.UpdateDefaultJobTimeout(bundle.DefaultJobTimeout); err != nil {
+		return importError("default job timeout", err)
+	}
+
+	if err := conf.importObjects(bundle); err != nil {
+		return err
+	}
+
+	if len(bundle.SystemAdmins.Roles) != 0 || len(bundle.SystemAdmins.Users) != 0 {
+		current, err := conf.GetSystemAdmins()
+		if err != nil {
+			return importError("system admins", err)
+		}
+
+		bundle.SystemAdmins.ETAG = current.ETAG
+
+		if _, err = conf.UpdateSystemAdmins(bundle.SystemAdmins); err != nil {
+			return importError("system admins", err)
+		}
+	}
+
+	return nil
+}
+
+// importObjects imports the objects of the bundle in the order of their dependencies,
+// e.g. auth configs before the roles and cluster profiles before the elastic agent profiles.
+func (conf *client) importObjects(bundle ServerConfigBundle) error {
+	importers := []func() error{
+		func() error {
+			return commonConfigImporter("auth config", conf.GetAuthConfig, conf.CreateAuthConfig, conf.UpdateAuthConfig).
+				run(bundle.AuthConfigs)
+		},
+		func() error {
+			return configImporter[Role]{
+				kind: "role",
+				key:  func(role Role) string { return role.Name },
+				get:  conf.GetRole,
+				create: func(role Role) error {
+					_, err := conf.CreateRole(role)
+
+					return err
+				},
+				update: func(role, current Role) error {
+					role.ETAG = current.ETAG
+					_, err := conf.UpdateRole(role)
+
+					return err
+				},
+			}.run(bundle.Roles)
+		},
+		func() error {
+			return configImporter[PluginSettings]{
+				kind: "plugin settings",
+				key:  func(settings PluginSettings) string { return settings.ID },
+				get:  conf.GetPluginSettings,
+				create: func(settings PluginSettings) error {
+					_, err := conf.CreatePluginSettings(settings)
+
+					return err
+				},
+				update: func(settings, current PluginSettings) error {
+					settings.ETAG = current.ETAG
+					_, err := conf.UpdatePluginSettings(settings)
+
+					return err
+				},
+			}.run(bundle.PluginSettings)
+		},
+		func() error {
+			return commonConfigImporter("secret config", conf.GetSecretConfig, conf.CreateSecretConfig, conf.UpdateSecretConfig).
+				run(bundle.SecretConfigs)
+		},
+		func() error {
+			return commonConfigImporter("cluster profile", conf.GetClusterProfile, conf.CreateClusterProfile, conf.UpdateClusterProfile).
+				run(bundle.ClusterProfiles)
+		},
+		func() error {
+			return commonConfigImporter("elastic agent profile",
+				conf.GetElasticAgentProfile, conf.CreateElasticAgentProfile, conf.UpdateElasticAgentProfile).
+				run(bundle.ElasticAgentProfiles)
+		},
+		func() error {
+			return commonConfigImporter("artifact store", conf.GetArtifactStore, conf.CreateArtifactStore, conf.UpdateArtifactStore).
+				run(bundle.ArtifactStores)
+		},
+		func() error {
+			return configImporter[PackageRepository]{
+				kind: "package repository",
+				key:  func(repo PackageRepository) string { return repo.ID },
+				get:  conf.GetPackageRepository,
+				create: func(repo PackageRepository) error {
+					_, err := conf.CreatePackageRepository(repo)
+
+					return err
+				},
+				update: func(repo, current PackageRepository) error {
+					repo.ETAG = current.ETAG
+					_, err := conf.UpdatePackageRepository(repo)
+
+					return err
+				},
+			}.run(bundle.PackageRepositories)
+		},
+		func() error {
+			return configImporter[Environment]{
+				kind:   "environment",
+				key:    func(environment Environment) string { return environment.Name },
+				get:    conf.GetEnvironment,
+				create: conf.CreateEnvironment,
+				update: func(environment, current Environment) error {
+					environment.Pipelines = current.Pipelines
+					environment.ETAG = current.ETAG
+					_, err := conf.UpdateEnvironment(environment)
+
+					return err
+				},
+			}.run(bundle.Environments)
+		},
+		func() error {
+			return configImporter[ConfigRepo]{
+				kind:   "config repo",
+				key:    func(repo ConfigRepo) string { return repo.ID },
+				get:    conf.GetConfigRepo,
+				create: conf.CreateConfigRepo,
+				update: func(repo, current ConfigRepo) error {
+					repo.ETAG = current.ETAG
+					_, err := conf.UpdateConfigRepo(repo)
+
+					return err
+				},
+			}.run(bundle.ConfigRepos)
+		},
+	}
+
+	for _, importer := range importers {
+		if err := importer(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commonConfigImporter returns the configImporter for the kinds that are represented by CommonConfig and identified by ID.
+func commonConfigImporter(kind string, get func(string) (CommonConfig, error),
+	create, update func(CommonConfig) (CommonConfig, error),
+) configImporter[CommonConfig] {
+	return configImporter[CommonConfig]{
+		kind: kind,
+		key:  func(config CommonConfig) string { return config.ID },
+		get:  get,
+		create: func(config CommonConfig) error {
+			_, err := create(config)
+
+			return err
+		},
+		update: func(config, current CommonConfig) error {
+			config.ETAG = current.ETAG
+			_, err := update(config)
+
+			return err
+		},
+	}
+}
+
+// exportPluginSettings fetches the settings of the plugins that support plugin settings and have them configured.
+func (conf *client) exportPluginSettings() ([]PluginSettings, error) {
+	pluginsInfo, err := conf.GetPluginsInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	pluginSettings := make([]PluginSettings, 0)
+
+	for _, plugin := range pluginsInfo.Plugins {
+		if !plugin.supportsPluginSettings() {
+			continue
+		}
+
+		settings, err := conf.GetPluginSettings(plugin.ID)
+		if errors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		pluginSettings = append(pluginSettings, settings)
+	}
+
+	return pluginSettings, nil
+}
+
+func (plugin *Plugin) supportsPluginSettings() bool {
+	for _, extension := range plugin.Extensions {
+		if extension.PluginSettings != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (environment Environment) definedInConfigRepo() bool {
+	if len(environment.Origins) == 0 {
+		return false
+	}
+
+	for _, origin := range environment.Origins {
+		if origin.Type != environmentOriginConfigRepo {
+			return false
+		}
+	}
+
+	return true
+}
+
+// clean drops the ETags and the information that GoCD computes, which should not be imported.
+func (bundle *ServerConfigBundle) clean() {
+	bundle.ArtifactConfig.ETAG = ""
+	bundle.SystemAdmins.ETAG = ""
+
+	for _, configs := range [][]CommonConfig{
+		bundle.AuthConfigs, bundle.SecretConfigs, bundle.ClusterProfiles, bundle.ElasticAgentProfiles, bundle.ArtifactStores,
+	} {
+		for index := range configs {
+			configs[index].ETAG = ""
+		}
+	}
+
+	for index := range bundle.Roles {
+		bundle.Roles[index].ETAG = ""
+	}
+
+	for index := range bundle.PluginSettings {
+		bundle.PluginSettings[index].ETAG = ""
+	}
+
+	for index := range bundle.PackageRepositories {
+		bundle.PackageRepositories[index].ETAG = ""
+		bundle.PackageRepositories[index].Packages.Packages = nil
+	}
+
+	for index := range bundle.ConfigRepos {
+		bundle.ConfigRepos[index] = ConfigRepo{
+			PluginID:      bundle.ConfigRepos[index].PluginID,
+			ID:            bundle.ConfigRepos[index].ID,
+			Material:      bundle.ConfigRepos[index].Material,
+			Configuration: bundle.ConfigRepos[index].Configuration,
+			Rules:         bundle.ConfigRepos[index].Rules,
+		}
+	}
+}
+
+// dropSecureValues drops the values encrypted by GoCD, recording their paths under SecureValues.
+func (bundle *ServerConfigBundle) dropSecureValues() {
+	bundle.SecureValues = nil
+
+	bundle.walkSecureValues(func(path string, _, encrypted *string) {
+		if len(*encrypted) == 0 {
+			return
+		}
+
+		*encrypted = ""
+		bundle.SecureValues = append(bundle.SecureValues, path)
+	})
+
+	slices.Sort(bundle.SecureValues)
+}
+
+// supplySecureValues sets the plain text of the secure values of the bundle, erroring out with the ones not supplied.
+func (bundle *ServerConfigBundle) supplySecureValues(values map[string]string) error {
+	if len(bundle.SecureValues) == 0 {
+		return nil
+	}
+
+	supplied := make(map[string]bool, len(bundle.SecureValues))
+
+	bundle.walkSecureValues(func(path string, value, encrypted *string) {
+		plain, ok := values[path]
+		if !ok || !slices.Contains(bundle.SecureValues, path) {
+			return
+		}
+
+		*value, *encrypted = plain, ""
+		supplied[path] = true
+	})
+
+	missing := slices.DeleteFunc(slices.Clone(bundle.SecureValues), func(path string) bool { return supplied[path] })
+	if len(missing) != 0 {
+		return &errors.GoCDSDKError{
+			Message: fmt.Sprintf("secure values [%s] of the server config bundle are not supplied", strings.Join(missing, ", ")),
+		}
+	}
+
+	return nil
+}
+
+// walkSecureValues calls visit with the path of each of the values of the bundle that GoCD encrypts, along with its plain text
+// and encrypted value. The slices holding them are copied, so the bundle passed by the caller is not modified.
+func (bundle *ServerConfigBundle) walkSecureValues(visit func(path string, value, encrypted *string)) {
+	if bundle.MailServer != nil {
+		mailServer := *bundle.MailServer
+		visit("mail_server/password", &mailServer.Password, &mailServer.EncryptedPassword)
+		bundle.MailServer = &mailServer
+	}
+
+	for _, commonConfigs := range []struct {
+		kind    string
+		configs *[]CommonConfig
+	}{
+		{kind: "auth_configs", configs: &bundle.AuthConfigs},
+		{kind: "secret_configs", configs: &bundle.SecretConfigs},
+		{kind: "cluster_profiles", configs: &bundle.ClusterProfiles},
+		{kind: "elastic_agent_profiles", configs: &bundle.ElasticAgentProfiles},
+		{kind: "artifact_stores", configs: &bundle.ArtifactStores},
+	} {
+		*commonConfigs.configs = slices.Clone(*commonConfigs.configs)
+
+		for index, config := range *commonConfigs.configs {
+			(*commonConfigs.configs)[index].Properties = walkProperties(commonConfigs.kind+"/"+config.ID, config.Properties, visit)
+		}
+	}
+
+	bundle.PluginSettings = slices.Clone(bundle.PluginSettings)
+	for index, settings := range bundle.PluginSettings {
+		configuration := make([]*PluginConfiguration, 0, len(settings.Configuration))
+
+		for _, property := range settings.Configuration {
+			if property == nil {
+				continue
+			}
+
+			property := *property
+			visit("plugin_settings/"+settings.ID+"/"+property.Key, &property.Value, &property.EncryptedValue)
+			configuration = append(configuration, &property)
+		}
+
+		bundle.PluginSettings[index].Configuration = configuration
+	}
+
+	bundle.PackageRepositories = slices.Clone(bundle.PackageRepositories)
+	for index, repo := range bundle.PackageRepositories {
+		bundle.PackageRepositories[index].Configuration = walkProperties("package_repositories/"+repo.ID, repo.Configuration, visit)
+	}
+
+	bundle.Environments = slices.Clone(bundle.Environments)
+	for index, environment := range bundle.Environments {
+		envVars := slices.Clone(environment.EnvVars)
+		for envIndex := range envVars {
+			visit("environments/"+environment.Name+"/"+envVars[envIndex].Name, &envVars[envIndex].Value, &envVars[envIndex].EncryptedValue)
+		}
+
+		bundle.Environments[index].EnvVars = envVars
+	}
+
+	bundle.ConfigRepos = slices.Clone(bundle.ConfigRepos)
+	for index := range bundle.ConfigRepos {
+		repo := &bundle.ConfigRepos[index]
+		repo.Configuration = walkProperties("config_repos/"+repo.ID, repo.Configuration, visit)
+		visit("config_repos/"+repo.ID+"/material/password", &repo.Material.Attributes.Password, &repo.Material.Attributes.EncryptedPassword)
+	}
+}
+
+func walkProperties(path string, properties []PluginConfiguration, visit func(path string, value, encrypted *string)) []PluginConfiguration {
+	properties = slices.Clone(properties)
+	for index := range properties {
+		visit(path+"/"+properties[index].Key, &properties[index].Value, &properties[index].EncryptedValue)
+	}
+
+	return properties
+}
@@ -0,0 +1,271 @@
+package gocd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/gocdtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_client_ExportServerConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	for path, body := range map[string]string{
+		gocd.SiteURLEndpoint:        siteURLJSON,
+		gocd.ArtifactInfoEndpoint:   artifactInfoJSON,
+		gocd.BackupConfigEndpoint:   backupJSON,
+		gocd.DefaultTimeoutEndpoint: `{"default_job_timeout": "5"}`,
+		gocd.SystemAdminEndpoint:    systemAdmins,
+		gocd.AuthConfigEndpoint:     authConfigsGetJSON,
+		gocd.RolesEndpoint:          rolesConfigsJSON,
+		gocd.PluginInfoEndpoint:     pluginsInfoJSON,
+		filepath.Join(gocd.PluginSettingsEndpoint, "json.config.plugin"): pluginSettingJSON,
+		gocd.SecretsConfigEndpoint:                                       secretConfigsJSON,
+		gocd.ClusterProfileEndpoint:                                      clusterProfilesJSON,
+		gocd.AgentProfileEndpoint:                                        elasticAgentProfilesJSON,
+		gocd.ArtifactStoreEndpoint:                                       artifactStoresJSON,
+		gocd.PackageRepositoriesEndpoint:                                 packageRepositoriesJSON,
+		gocd.EnvironmentEndpoint:                                         environmentsJSON,
+		gocd.ConfigReposEndpoint:                                         configReposJSON,
+	} {
+		mux.HandleFunc("GET "+path, func(writer http.ResponseWriter, _ *http.Request) {
+			writer.Header().Set("ETag", "etag-1")
+			_, _ = writer.Write([]byte(body))
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	client := gocd.NewClient(server.URL, auth, "info", nil)
+
+	t.Run("should export the server configuration without ETags and pipelines of the environments", func(t *testing.T) {
+		bundle, err := client.ExportServerConfig()
+		require.NoError(t, err)
+
+		assert.Equal(t, gocd.ServerConfigBundleVersion, bundle.Version)
+		assert.Equal(t, gocd.SiteURLConfig{SiteURL: "http://foo.com", SecureSiteURL: "https://foo.com"}, bundle.SiteURL)
+		assert.Nil(t, bundle.MailServer)
+		assert.Equal(t, "0 0 2 * * ?", bundle.Backup.Schedule)
+		assert.Equal(t, 5, bundle.DefaultJobTimeout)
+		assert.Equal(t, gocd.SystemAdmins{Roles: []string{"manager"}, Users: []string{"john", "maria"}}, bundle.SystemAdmins)
+		assert.Equal(t, gocd.ArtifactInfo{
+			ArtifactsDir:  "foo",
+			PurgeSettings: gocd.PurgeSettings{PurgeStartDiskSpace: 10, PurgeUptoDiskSpace: 20},
+		}, bundle.ArtifactConfig)
+
+		require.Len(t, bundle.PluginSettings, 1)
+		assert.Equal(t, "github.oauth.login", bundle.PluginSettings[0].ID)
+		assert.Empty(t, bundle.PluginSettings[0].ETAG)
+
+		require.Len(t, bundle.Environments, 2)
+		assert.Equal(t, "foobar1", bundle.Environments[0].Name)
+		assert.Empty(t, bundle.Environments[0].Pipelines)
+		assert.Len(t, bundle.Environments[0].EnvVars, 2)
+
+		require.Len(t, bundle.ConfigRepos, 1)
+		assert.Equal(t, "repo1", bundle.ConfigRepos[0].ID)
+		assert.NotEmpty(t, bundle.AuthConfigs)
+		assert.NotEmpty(t, bundle.Roles)
+		assert.NotEmpty(t, bundle.SecretConfigs)
+		assert.NotEmpty(t, bundle.ClusterProfiles)
+		assert.NotEmpty(t, bundle.ElasticAgentProfiles)
+		assert.NotEmpty(t, bundle.ArtifactStores)
+		assert.NotEmpty(t, bundle.PackageRepositories)
+	})
+
+	t.Run("should error out when one of the configurations could not be exported", func(t *testing.T) {
+		server = httptest.NewServer(http.NotFoundHandler())
+		client = gocd.NewClient(server.URL, auth, "info", nil)
+
+		_, err := client.ExportServerConfig()
+		require.EqualError(t, err, "exporting site url errored with got 404 from GoCD while making GET call for "+server.URL+
+			"/api/admin/config/server/site_urls\nwith BODY:404 page not found\n")
+	})
+}
+
+func Test_client_ImportServerConfig(t *testing.T) {
+	t.Run("should import the bundle in the order of dependencies updating the existing objects with their ETag", func(t *testing.T) {
+		existing := map[string]bool{
+			gocd.ArtifactInfoEndpoint:                     true,
+			gocd.SystemAdminEndpoint:                      true,
+			filepath.Join(gocd.RolesEndpoint, "admins"):   true,
+			filepath.Join(gocd.EnvironmentEndpoint, "qa"): true,
+		}
+
+		calls := make([]string, 0)
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			calls = append(calls, req.Method+" "+req.URL.Path+" "+req.Header.Get("If-Match"))
+
+			if req.Method == http.MethodGet && !existing[req.URL.Path] {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			writer.Header().Set("ETag", "etag-1")
+			_, _ = writer.Write([]byte(`{}`))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.ImportServerConfig(gocd.ServerConfigBundle{
+			Version:              gocd.ServerConfigBundleVersion,
+			SiteURL:              gocd.SiteURLConfig{SiteURL: "http://foo.com"},
+			ArtifactConfig:       gocd.ArtifactInfo{ArtifactsDir: "foo"},
+			DefaultJobTimeout:    10,
+			SystemAdmins:         gocd.SystemAdmins{Users: []string{"alice"}},
+			AuthConfigs:          []gocd.CommonConfig{{ID: "ldap"}},
+			Roles:                []gocd.Role{{Name: "admins"}},
+			ClusterProfiles:      []gocd.CommonConfig{{ID: "docker"}},
+			ElasticAgentProfiles: []gocd.CommonConfig{{ID: "ubuntu", ClusterProfileID: "docker"}},
+			Environments:         []gocd.Environment{{Name: "qa"}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"POST /api/admin/config/server/site_urls ",
+			"GET /api/admin/config/server/artifact_config ",
+			"PUT /api/admin/config/server/artifact_config etag-1",
+			"POST /api/admin/config/server/default_job_timeout ",
+			"GET /api/admin/security/auth_configs/ldap ",
+			"POST /api/admin/security/auth_configs ",
+			"GET /api/admin/security/roles/admins ",
+			"PUT /api/admin/security/roles/admins etag-1",
+			// the version of the server is detected on the first call made to the endpoints negotiated by default.
+			"GET /api/version ",
+			"GET /api/admin/elastic/cluster_profiles/docker ",
+			"POST /api/admin/elastic/cluster_profiles ",
+			"GET /api/elastic/profiles/ubuntu ",
+			"POST /api/elastic/profiles ",
+			"GET /api/admin/environments/qa ",
+			"PUT /api/admin/environments/qa etag-1",
+			"GET /api/admin/security/system_admins ",
+			"PUT /api/admin/security/system_admins etag-1",
+		}, calls)
+	})
+
+	t.Run("should error out while importing the bundle of unsupported version", func(t *testing.T) {
+		client := gocd.NewClient("http://localhost:8156/go", auth, "info", nil)
+
+		err := client.ImportServerConfig(gocd.ServerConfigBundle{Version: 2})
+		require.EqualError(t, err, "server config bundle of version 2 is not supported, supported version is 1")
+	})
+
+	t.Run("should error out with the object that could not be imported", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			if req.URL.Path == gocd.RolesEndpoint {
+				writer.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = writer.Write([]byte(`{"message": "validation failed"}`))
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{}`))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		err := client.ImportServerConfig(gocd.ServerConfigBundle{Version: gocd.ServerConfigBundleVersion, Roles: []gocd.Role{{Name: "admins"}}})
+		require.EqualError(t, err, "importing role 'admins' errored with got 422 from GoCD while making POST call for "+server.URL+
+			"/api/admin/security/roles\nwith BODY:{\"message\": \"validation failed\"}")
+	})
+}
+
+// serverConfigServer fronts the fake GoCD server with the endpoints of the server configuration that it does not serve.
+func serverConfigServer(t *testing.T, fake *gocdtest.Server) *httptest.Server {
+	t.Helper()
+
+	target, err := url.Parse(fake.URL)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", httputil.NewSingleHostReverseProxy(target))
+
+	for path, body := range map[string]string{
+		gocd.SiteURLEndpoint:             `{}`,
+		gocd.ArtifactInfoEndpoint:        `{}`,
+		gocd.DefaultTimeoutEndpoint:      `{"default_job_timeout": "0"}`,
+		gocd.SystemAdminEndpoint:         `{}`,
+		gocd.PluginInfoEndpoint:          `{}`,
+		gocd.ArtifactStoreEndpoint:       `{}`,
+		gocd.PackageRepositoriesEndpoint: `{}`,
+	} {
+		mux.HandleFunc(path, func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = writer.Write([]byte(body))
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_client_ServerConfigRoundTrip(t *testing.T) {
+	source := gocdtest.NewServer()
+	defer source.Close()
+
+	target := gocdtest.NewServer()
+	defer target.Close()
+
+	source.AddAuthConfigs(gocd.CommonConfig{
+		ID:       "ldap",
+		PluginID: "cd.go.authentication.ldap",
+		Properties: []gocd.PluginConfiguration{
+			{Key: "Url", Value: "ldap://ldap.example.com"},
+			{Key: "Password", Value: "ldap-secret", IsSecure: true},
+		},
+	})
+	source.AddEnvironments(gocd.Environment{Name: "prod", EnvVars: []gocd.EnvVars{{Name: "DB_PASS", Value: "db-secret", Secure: true}}})
+
+	sourceClient := gocd.NewClient(serverConfigServer(t, source).URL, auth, "info", nil)
+	targetClient := gocd.NewClient(serverConfigServer(t, target).URL, auth, "info", nil)
+
+	bundle, err := sourceClient.ExportServerConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"auth_configs/ldap/Password", "environments/prod/DB_PASS"}, bundle.SecureValues)
+	assert.Equal(t, gocd.PluginConfiguration{Key: "Password", IsSecure: true}, bundle.AuthConfigs[0].Properties[1])
+
+	t.Run("should error out without importing anything when the secure values are not supplied", func(t *testing.T) {
+		err = targetClient.ImportServerConfig(bundle, gocd.WithSecureValues(map[string]string{"auth_configs/ldap/Password": "ldap-secret"}))
+		require.EqualError(t, err, "secure values [environments/prod/DB_PASS] of the server config bundle are not supplied")
+
+		_, err = targetClient.GetAuthConfig("ldap")
+		require.Error(t, err)
+	})
+
+	t.Run("should import the secure values supplied, encrypting them with the cipher of the target server", func(t *testing.T) {
+		err = targetClient.ImportServerConfig(bundle, gocd.WithSecureValues(map[string]string{
+			"auth_configs/ldap/Password": "ldap-secret",
+			"environments/prod/DB_PASS":  "db-secret",
+		}))
+		require.NoError(t, err)
+
+		sourceConfig, err := sourceClient.GetAuthConfig("ldap")
+		require.NoError(t, err)
+
+		targetConfig, err := targetClient.GetAuthConfig("ldap")
+		require.NoError(t, err)
+		assert.Equal(t, "ldap://ldap.example.com", targetConfig.Properties[0].Value)
+		assert.NotEmpty(t, targetConfig.Properties[1].EncryptedValue)
+		assert.NotEqual(t, sourceConfig.Properties[1].EncryptedValue, targetConfig.Properties[1].EncryptedValue)
+
+		environment, err := targetClient.GetEnvironment("prod")
+		require.NoError(t, err)
+		assert.NotEmpty(t, environment.EnvVars[0].EncryptedValue)
+		assert.Empty(t, environment.EnvVars[0].Value)
+
+		assert.Empty(t, bundle.AuthConfigs[0].Properties[1].Value, "bundle passed should not be modified")
+	})
+}
@@ -2,21 +2,17 @@ package gocd
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"path/filepath"
+	"time"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 // RunStage runs a selected stage from an appropriate pipeline.
 func (conf *client) RunStage(stage Stage) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionTwo,
 			HeaderConfirm: "true",
@@ -41,12 +37,7 @@ func (conf *client) RunStage(stage Stage) (string, error) {
 
 // CancelStage cancels the selected stage from a selected pipeline.
 func (conf *client) CancelStage(stage Stage) (string, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return "", err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":      HeaderVersionThree,
 			HeaderConfirm: "true",
@@ -68,3 +59,119 @@ func (conf *client) CancelStage(stage Stage) (string, error) {
 
 	return message["message"], nil
 }
+
+// GetStageInstance fetches the information of a specific run of the stage, identified by pipeline counter and stage counter.
+func (conf *client) GetStageInstance(stage Stage) (StageInstance, error) {
+	var stageInstance StageInstance
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionThree,
+		}).
+		Get(filepath.Join(StageEndpoint, stage.Pipeline, stage.PipelineInstance, stage.Name, stage.StageCounter))
+	if err != nil {
+		return stageInstance, &errors.APIError{Err: err, Message: fmt.Sprintf("get stage instance '%s/%s/%s/%s'",
+			stage.Pipeline, stage.PipelineInstance, stage.Name, stage.StageCounter)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return stageInstance, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &stageInstance); err != nil {
+		return stageInstance, &errors.MarshalError{Err: err}
+	}
+
+	return stageInstance, nil
+}
+
+// GetStageHistory fetches a page of run history of the selected stage, latest runs are returned first.
+// Pass the Next cursor of the returned StageHistory as after to fetch the subsequent page, empty after fetches the first page.
+func (conf *client) GetStageHistory(pipeline, stage, pageSize, after string) (StageHistory, error) {
+	type stageHistory struct {
+		Links  pageLinks       `json:"_links,omitempty"`
+		Stages []StageInstance `json:"stages,omitempty"`
+	}
+
+	queryParams := map[string]string{"page_size": pageSize}
+	if len(after) != 0 {
+		queryParams["after"] = after
+	}
+
+	var history stageHistory
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionThree,
+		}).
+		SetQueryParams(queryParams).
+		Get(filepath.Join(StageEndpoint, pipeline, stage, "history"))
+	if err != nil {
+		return StageHistory{}, &errors.APIError{Err: err, Message: fmt.Sprintf("get stage history for '%s/%s'", pipeline, stage)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return StageHistory{}, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &history); err != nil {
+		return StageHistory{}, &errors.MarshalError{Err: err}
+	}
+
+	return StageHistory{
+		Stages:   history.Stages,
+		Next:     history.Links.Next.cursor("after"),
+		Previous: history.Links.Previous.cursor("before"),
+	}, nil
+}
+
+// Duration returns the time taken by the stage run, computed from the state transitions of its jobs.
+// Zero is returned if any of the jobs are yet to complete.
+func (stage StageInstance) Duration() time.Duration {
+	var scheduled, completed int64
+
+	for _, job := range stage.Jobs {
+		jobScheduled, jobCompleted := job.transitionTime(jobStateScheduled), job.transitionTime(jobStateCompleted)
+		if jobCompleted == 0 {
+			return 0
+		}
+
+		if scheduled == 0 || (jobScheduled != 0 && jobScheduled < scheduled) {
+			scheduled = jobScheduled
+		}
+
+		if jobCompleted > completed {
+			completed = jobCompleted
+		}
+	}
+
+	if scheduled == 0 {
+		return 0
+	}
+
+	return time.Duration(completed-scheduled) * time.Millisecond
+}
+
+// FailedJobs returns the names of the jobs of the stage run that completed without passing, e.g. the failed or cancelled ones.
+// The jobs yet to complete are not considered.
+func (stage StageInstance) FailedJobs() []string {
+	failedJobs := make([]string, 0)
+
+	for _, job := range stage.Jobs {
+		if job.State == jobStateCompleted && job.Result != jobResultPassed {
+			failedJobs = append(failedJobs, job.Name)
+		}
+	}
+
+	return failedJobs
+}
+
+func (job JobInstance) transitionTime(state string) int64 {
+	for _, transition := range job.JobStateTransitions {
+		if transition.State == state {
+			return transition.StateChangeTime
+		}
+	}
+
+	return 0
+}
@@ -1,14 +1,23 @@
 package gocd_test
 
 import (
+	_ "embed"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/nikhilsbhat/gocd-sdk-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+var (
+	//go:embed internal/fixtures/stage_instance.json
+	stageInstanceJSON string
+	//go:embed internal/fixtures/stage_history.json
+	stageHistoryJSON string
+)
+
 func Test_client_RunStage(t *testing.T) {
 	correctJobsHeader := map[string]string{"Accept": gocd.HeaderVersionTwo, gocd.HeaderConfirm: "true"}
 
@@ -113,3 +122,81 @@ func Test_client_CancelStage(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func Test_client_GetStageInstance(t *testing.T) {
+	correctStageHeader := map[string]string{"Accept": gocd.HeaderVersionThree}
+	stage := gocd.Stage{Pipeline: "up42", PipelineInstance: "2", Name: "up42_stage", StageCounter: "1"}
+
+	t.Run("should be able to fetch the stage instance", func(t *testing.T) {
+		server := mockServer([]byte(stageInstanceJSON), http.StatusOK, correctStageHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetStageInstance(stage)
+		require.NoError(t, err)
+		assert.Equal(t, "up42_stage", actual.Name)
+		assert.Equal(t, int64(1), actual.Counter)
+		assert.Equal(t, "Failed", actual.Result)
+		assert.Equal(t, "changes", actual.ApprovedBy)
+		assert.Equal(t, int64(2), actual.PipelineCounter)
+		assert.Len(t, actual.Jobs, 2)
+		assert.Equal(t, []string{"up42_job_2"}, actual.FailedJobs())
+		assert.Equal(t, 100*time.Second, actual.Duration())
+	})
+
+	t.Run("should consider the jobs completed without passing as failed", func(t *testing.T) {
+		stageInstance := gocd.StageInstance{Jobs: []gocd.JobInstance{
+			{Name: "passed", State: "Completed", Result: "Passed"},
+			{Name: "failed", State: "Completed", Result: "Failed"},
+			{Name: "cancelled", State: "Completed", Result: "Cancelled"},
+			{Name: "building", State: "Building", Result: "Unknown"},
+		}}
+
+		assert.Equal(t, []string{"failed", "cancelled"}, stageInstance.FailedJobs())
+	})
+
+	t.Run("should error out while fetching the stage instance as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("stageInstanceJSON"), http.StatusNotFound, correctStageHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetStageInstance(stage)
+		require.EqualError(t, err, "got 404 from GoCD while making GET call for "+server.URL+
+			"/api/stages/up42/2/up42_stage/1\nwith BODY:stageInstanceJSON")
+		assert.Equal(t, gocd.StageInstance{}, actual)
+	})
+
+	t.Run("should error out while fetching the stage instance as server returned malformed response", func(t *testing.T) {
+		server := mockServer([]byte(`{"name"}`), http.StatusOK, correctStageHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetStageInstance(stage)
+		require.EqualError(t, err, "reading response body errored with: invalid character '}' after object key")
+		assert.Equal(t, gocd.StageInstance{}, actual)
+	})
+}
+
+func Test_client_GetStageHistory(t *testing.T) {
+	correctStageHeader := map[string]string{"Accept": gocd.HeaderVersionThree}
+
+	t.Run("should be able to fetch a page of stage history along with the cursors", func(t *testing.T) {
+		server := mockServer([]byte(stageHistoryJSON), http.StatusOK, correctStageHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetStageHistory("up42", "up42_stage", "2", "")
+		require.NoError(t, err)
+		assert.Len(t, actual.Stages, 2)
+		assert.Equal(t, int64(5), actual.Stages[0].PipelineCounter)
+		assert.Equal(t, "Failed", actual.Stages[1].Result)
+		assert.Equal(t, "3", actual.Next)
+		assert.Equal(t, "5", actual.Previous)
+	})
+
+	t.Run("should error out while fetching stage history as server returned non 200 status code", func(t *testing.T) {
+		server := mockServer([]byte("stageHistoryJSON"), http.StatusBadGateway, correctStageHeader, false, nil)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		actual, err := client.GetStageHistory("up42", "up42_stage", "2", "3")
+		require.EqualError(t, err, "got 502 from GoCD while making GET call for "+server.URL+
+			"/api/stages/up42/up42_stage/history?after=3&page_size=2\nwith BODY:stageHistoryJSON")
+		assert.Equal(t, gocd.StageHistory{}, actual)
+	})
+}
@@ -0,0 +1,258 @@
+package gocd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+)
+
+// TriggerOption customises how TriggerAndWait waits for the pipeline run to complete.
+type TriggerOption func(opts *triggerOptions)
+
+type triggerOptions struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// pipelineRun holds the state of a pipeline run as returned by the pipeline instance API.
+type pipelineRun struct {
+	Name    string             `json:"name,omitempty"`
+	Counter int                `json:"counter,omitempty"`
+	Stages  []pipelineRunStage `json:"stages,omitempty"`
+}
+
+type pipelineRunStage struct {
+	Name         string `json:"name,omitempty"`
+	Counter      string `json:"counter,omitempty"`
+	Scheduled    bool   `json:"scheduled,omitempty"`
+	ApprovalType string `json:"approval_type,omitempty"`
+	Result       string `json:"result,omitempty"`
+}
+
+// WithPollInterval sets the time to wait between the checks of the pipeline run, defaults to 10 seconds.
+func WithPollInterval(interval time.Duration) TriggerOption {
+	return func(opts *triggerOptions) {
+		opts.interval = interval
+	}
+}
+
+// WithWaitTimeout sets the maximum time to wait for the pipeline to be scheduled and its run to complete, defaults to an hour.
+func WithWaitTimeout(timeout time.Duration) TriggerOption {
+	return func(opts *triggerOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// TriggerAndWait schedules the pipeline, detects the counter of the run created from the history of the pipeline
+// and waits for the run to complete, returning the outcome of its stages and jobs.
+// The run is considered complete when a stage did not pass, or when all the stages passed except the ones awaiting manual approval.
+// When the wait times out, the outcome collected so far is returned along with *errors.PipelineWaitTimeoutError,
+// which matches context.DeadlineExceeded.
+//
+// The run created is taken to be the latest run in the history once its counter is greater than the latest counter before
+// scheduling, as GoCD does not return the counter of the run it schedules. So when the pipeline is scheduled by someone else or by a change
+// of its materials at about the same time, the run waited for might not be the one scheduled by the call.
+func (conf *client) TriggerAndWait(name string, schedule Schedule, opts ...TriggerOption) (PipelineRunResult, error) {
+	options := triggerOptions{
+		interval: defaultTriggerPollInterval * time.Second,
+		timeout:  defaultTriggerTimeout * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	result := PipelineRunResult{Name: name, Result: stageResultUnknown}
+
+	previous, err := conf.latestPipelineCounter(name)
+	if err != nil {
+		return result, err
+	}
+
+	if err = conf.SchedulePipeline(name, schedule); err != nil {
+		return result, err
+	}
+
+	timeout := time.NewTimer(options.timeout)
+	defer timeout.Stop()
+
+	for result.Counter <= previous {
+		if err = conf.waitForNextPoll(name, options, timeout); err != nil {
+			return result, err
+		}
+
+		if result.Counter, err = conf.latestPipelineCounter(name); err != nil {
+			return result, err
+		}
+	}
+
+	conf.logger.Debugf("pipeline '%s' was scheduled with counter %d, waiting for it to complete", name, result.Counter)
+
+	var run pipelineRun
+
+	for {
+		if run, err = conf.getPipelineRun(name, result.Counter); err != nil {
+			return result, err
+		}
+
+		if pendingStage, completed := run.completed(); completed {
+			result.PendingStage = pendingStage
+
+			return conf.collectPipelineRunResult(result, run)
+		}
+
+		if err = conf.waitForNextPoll(name, options, timeout); err != nil {
+			return result, err
+		}
+	}
+}
+
+// Passed reports whether all the stages of the pipeline run passed, stages awaiting manual approval are not considered.
+func (result PipelineRunResult) Passed() bool {
+	return result.Result == stageResultPassed
+}
+
+// waitForNextPoll waits for the poll interval, erroring out if the wait timed out or the context of the client is done meanwhile.
+func (conf *client) waitForNextPoll(name string, options triggerOptions, timeout *time.Timer) error {
+	select {
+	case <-conf.getContext().Done():
+		return &errors.APIError{Err: conf.getContext().Err(), Message: fmt.Sprintf("wait for pipeline '%s'", name)}
+	case <-timeout.C:
+		return &errors.PipelineWaitTimeoutError{Pipeline: name, Timeout: options.timeout}
+	case <-time.After(options.interval):
+		return nil
+	}
+}
+
+// latestPipelineCounter returns the counter of the latest run of the pipeline, zero when it never ran.
+func (conf *client) latestPipelineCounter(name string) (int, error) {
+	runs, _, err := conf.getPipelineRunHistoryPage(name, strconv.Itoa(defaultHistoryPageSize), "")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int
+
+	for _, run := range runs {
+		latest = max(latest, run.Counter)
+	}
+
+	return latest, nil
+}
+
+func (conf *client) getPipelineRun(name string, counter int) (pipelineRun, error) {
+	var run pipelineRun
+
+	resp, err := conf.request().
+		SetHeaders(map[string]string{
+			"Accept": HeaderVersionOne,
+		}).
+		Get(filepath.Join(PipelinesEndpoint, name, strconv.Itoa(counter)))
+	if err != nil {
+		return run, &errors.APIError{Err: err, Message: fmt.Sprintf("fetch pipeline instance '%s'", name)}
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return run, &errors.NonOkError{Code: resp.StatusCode(), Response: resp}
+	}
+
+	if err = json.Unmarshal(resp.Body(), &run); err != nil {
+		return run, &errors.MarshalError{Err: err}
+	}
+
+	return run, nil
+}
+
+// collectPipelineRunResult fetches the instances of the stages that ran to report the outcome of their jobs.
+func (conf *client) collectPipelineRunResult(result PipelineRunResult, run pipelineRun) (PipelineRunResult, error) {
+	var scheduled, completed int64
+
+	result.Result = stageResultPassed
+	result.FailedJobs = make([]string, 0)
+
+	for _, stage := range run.Stages {
+		if !stage.Scheduled {
+			break
+		}
+
+		instance, err := conf.GetStageInstance(Stage{
+			Pipeline:         run.Name,
+			PipelineInstance: strconv.Itoa(run.Counter),
+			Name:             stage.Name,
+			StageCounter:     stage.Counter,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		stageResult := StageRunResult{
+			Name:     instance.Name,
+			Counter:  instance.Counter,
+			Result:   stage.Result,
+			Duration: instance.Duration(),
+			Jobs:     make([]JobRunResult, 0, len(instance.Jobs)),
+		}
+
+		for _, job := range instance.Jobs {
+			jobScheduled, jobCompleted := job.transitionTime(jobStateScheduled), job.transitionTime(jobStateCompleted)
+			if scheduled == 0 || (jobScheduled != 0 && jobScheduled < scheduled) {
+				scheduled = jobScheduled
+			}
+
+			if jobCompleted > completed {
+				completed = jobCompleted
+			}
+
+			jobResult := JobRunResult{Name: job.Name, State: job.State, Result: job.Result}
+			if jobScheduled != 0 && jobCompleted != 0 {
+				jobResult.Duration = time.Duration(jobCompleted-jobScheduled) * time.Millisecond
+			}
+
+			stageResult.Jobs = append(stageResult.Jobs, jobResult)
+		}
+
+		for _, job := range instance.FailedJobs() {
+			result.FailedJobs = append(result.FailedJobs, stage.Name+"/"+job)
+		}
+
+		if stage.Result != stageResultPassed && result.Result == stageResultPassed {
+			result.Result = stage.Result
+		}
+
+		result.Stages = append(result.Stages, stageResult)
+	}
+
+	if scheduled != 0 && completed > scheduled {
+		result.Duration = time.Duration(completed-scheduled) * time.Millisecond
+	}
+
+	return result, nil
+}
+
+// completed reports whether the pipeline run is complete, along with the name of the stage awaiting manual approval, if any.
+func (run pipelineRun) completed() (string, bool) {
+	for _, stage := range run.Stages {
+		if !stage.Scheduled {
+			if stage.ApprovalType == stageApprovalManual {
+				return stage.Name, true
+			}
+
+			return "", false
+		}
+
+		switch stage.Result {
+		case stageResultPassed:
+			continue
+		case stageResultUnknown, "":
+			return "", false
+		default:
+			return "", true
+		}
+	}
+
+	return "", true
+}
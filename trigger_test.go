@@ -0,0 +1,144 @@
+package gocd_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nikhilsbhat/gocd-sdk-go"
+	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// triggerServer serves the pipeline up42 whose latest run is 1 until it is scheduled,
+// the run 2 scheduled then builds for the number of polls specified before completing with the stages specified.
+func triggerServer(builds int, stages string) *httptest.Server {
+	var scheduled bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/pipelines/up42/history", func(writer http.ResponseWriter, req *http.Request) {
+		if pageSize, err := strconv.Atoi(req.URL.Query().Get("page_size")); err != nil || pageSize < 10 || pageSize > 100 {
+			writer.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = writer.Write([]byte(`{"message": "The query parameter 'page_size', if specified must be a number between 10 and 100."}`))
+
+			return
+		}
+
+		if scheduled {
+			_, _ = writer.Write([]byte(`{"pipelines": [{"name": "up42", "counter": 2}, {"name": "up42", "counter": 1}]}`))
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"pipelines": [{"name": "up42", "counter": 1}]}`))
+	})
+	mux.HandleFunc("POST /api/pipelines/up42/schedule", func(writer http.ResponseWriter, _ *http.Request) {
+		scheduled = true
+
+		writer.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("GET /api/pipelines/up42/2", func(writer http.ResponseWriter, _ *http.Request) {
+		if builds > 0 {
+			builds--
+			_, _ = writer.Write([]byte(`{"name": "up42", "counter": 2, "stages": [{"name": "up42_stage", "counter": "1", "scheduled": true, "result": "Unknown"}]}`))
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(writer, `{"name": "up42", "counter": 2, "stages": %s}`, stages)
+	})
+	mux.HandleFunc("GET /api/stages/up42/2/up42_stage/1", func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(stageInstanceJSON))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func Test_client_TriggerAndWait(t *testing.T) {
+	t.Run("should trigger the pipeline and wait for the run created to complete", func(t *testing.T) {
+		server := triggerServer(2, `[
+			{"name": "up42_stage", "counter": "1", "scheduled": true, "result": "Failed"},
+			{"name": "deploy", "scheduled": false, "approval_type": "success"}
+		]`)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		result, err := client.TriggerAndWait("up42", gocd.Schedule{}, gocd.WithPollInterval(time.Millisecond))
+		require.NoError(t, err)
+
+		assert.False(t, result.Passed())
+		assert.Equal(t, gocd.PipelineRunResult{
+			Name:       "up42",
+			Counter:    2,
+			Result:     "Failed",
+			Duration:   100 * time.Second,
+			FailedJobs: []string{"up42_stage/up42_job_2"},
+			Stages: []gocd.StageRunResult{
+				{
+					Name:     "up42_stage",
+					Counter:  1,
+					Result:   "Failed",
+					Duration: 100 * time.Second,
+					Jobs: []gocd.JobRunResult{
+						{Name: "up42_job", State: "Completed", Result: "Passed", Duration: 60 * time.Second},
+						{Name: "up42_job_2", State: "Completed", Result: "Failed", Duration: 100 * time.Second},
+					},
+				},
+			},
+		}, result)
+	})
+
+	t.Run("should consider the run complete when the stages ran passed and the next one awaits manual approval", func(t *testing.T) {
+		server := triggerServer(0, `[
+			{"name": "up42_stage", "counter": "1", "scheduled": true, "result": "Passed"},
+			{"name": "deploy", "scheduled": false, "approval_type": "manual"}
+		]`)
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		result, err := client.TriggerAndWait("up42", gocd.Schedule{}, gocd.WithPollInterval(time.Millisecond))
+		require.NoError(t, err)
+
+		assert.True(t, result.Passed())
+		assert.Equal(t, "deploy", result.PendingStage)
+		assert.Len(t, result.Stages, 1)
+	})
+
+	t.Run("should error out with the run detected when it does not complete in time", func(t *testing.T) {
+		server := triggerServer(1000, "[]")
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		result, err := client.TriggerAndWait("up42", gocd.Schedule{},
+			gocd.WithPollInterval(time.Millisecond), gocd.WithWaitTimeout(50*time.Millisecond))
+		require.EqualError(t, err, "pipeline 'up42' did not complete within 50ms")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var timeoutErr *errors.PipelineWaitTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+
+		assert.Equal(t, 2, result.Counter)
+		assert.Equal(t, "Unknown", result.Result)
+	})
+
+	t.Run("should error out when the pipeline could not be scheduled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodPost {
+				writer.WriteHeader(http.StatusConflict)
+				_, _ = writer.Write([]byte(`{"message": "Failed to trigger pipeline [up42] { Pipeline already triggered }"}`))
+
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{"pipelines": []}`))
+		}))
+		client := gocd.NewClient(server.URL, auth, "info", nil)
+
+		_, err := client.TriggerAndWait("up42", gocd.Schedule{})
+		require.EqualError(t, err, "got 409 from GoCD while making POST call for "+server.URL+
+			"/api/pipelines/up42/schedule\nwith BODY:{\"message\": \"Failed to trigger pipeline [up42] { Pipeline already triggered }\"}")
+	})
+}
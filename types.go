@@ -1,10 +1,30 @@
 package gocd
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 const (
 	defaultRetryCount    = 5
 	defaultRetryWaitTime = 5
+	// defaultCacheTTL is the minutes for which the responses of the methods returning the data that rarely changes are cached,
+	// defaultLongCacheTTL is for the ones which change only when GoCD is upgraded or its plugins are installed.
+	defaultCacheTTL     = 1
+	defaultLongCacheTTL = 10
+	// defaultArtifactZipWaitTime is the seconds to wait before checking again if GoCD has prepared the zip of artifact folder.
+	defaultArtifactZipWaitTime = 2
+	defaultHistoryPageSize     = 10
+	// maxHistoryPageSize is the largest number of runs GoCD returns per page of the history, the smallest is defaultHistoryPageSize.
+	maxHistoryPageSize = 100
+	// defaultModifyAttempts is the times an object is fetched, mutated and updated before giving up on ETag conflicts.
+	defaultModifyAttempts = 3
+	// defaultTriggerPollInterval is the seconds to wait before checking again if the pipeline triggered has completed.
+	defaultTriggerPollInterval = 10
+	// defaultTriggerTimeout is the minutes to wait for the pipeline triggered to complete.
+	defaultTriggerTimeout = 60
+	// defaultVersionProbeBackoff is the seconds to wait after failing to detect the version of GoCD before detecting it again.
+	defaultVersionProbeBackoff = 10
 )
 
 // AgentsConfig holds information of all agent of GoCD.
@@ -338,12 +358,19 @@ type AgentJobHistory struct {
 
 // JobRunHistory holds information of pipeline run history of a specific GoCD agent.
 type JobRunHistory struct {
-	Name            string `json:"pipeline_name,omitempty" yaml:"pipeline_name,omitempty"`
-	JobName         string `json:"job_name,omitempty" yaml:"job_name,omitempty"`
-	StageName       string `json:"stage_name,omitempty" yaml:"stage_name,omitempty"`
-	StageCounter    int64  `json:"stage_counter,string,omitempty" yaml:"stage_counter,string,omitempty"`
-	PipelineCounter int64  `json:"pipeline_counter,omitempty" yaml:"pipeline_counter,omitempty"`
-	Result          string `json:"result,omitempty" yaml:"result,omitempty"`
+	Name                string                  `json:"pipeline_name,omitempty" yaml:"pipeline_name,omitempty"`
+	JobName             string                  `json:"job_name,omitempty" yaml:"job_name,omitempty"`
+	StageName           string                  `json:"stage_name,omitempty" yaml:"stage_name,omitempty"`
+	StageCounter        int64                   `json:"stage_counter,string,omitempty" yaml:"stage_counter,string,omitempty"`
+	PipelineCounter     int64                   `json:"pipeline_counter,omitempty" yaml:"pipeline_counter,omitempty"`
+	Result              string                  `json:"result,omitempty" yaml:"result,omitempty"`
+	JobStateTransitions []JobRunStateTransition `json:"job_state_transitions,omitempty" yaml:"job_state_transitions,omitempty"`
+}
+
+// JobRunStateTransition holds the time at which the job run on the agent moved to a specific state.
+type JobRunStateTransition struct {
+	State           string `json:"state,omitempty" yaml:"state,omitempty"`
+	StateChangeTime string `json:"state_change_time,omitempty" yaml:"state_change_time,omitempty"`
 }
 
 // Pagination holds information which is helpful in paginating the results of job run history.
@@ -416,6 +443,7 @@ type MailServerConfig struct {
 	Hostname          string `json:"hostname,omitempty" yaml:"hostname,omitempty"`
 	Port              int64  `json:"port,omitempty" yaml:"port,omitempty"`
 	UserName          string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password          string `json:"password,omitempty" yaml:"password,omitempty"`
 	EncryptedPassword string `json:"encrypted_password,omitempty" yaml:"encrypted_password,omitempty"`
 	TLS               bool   `json:"tls,omitempty" yaml:"tls,omitempty"`
 	SenderEmail       string `json:"sender_email,omitempty" yaml:"sender_email,omitempty"`
@@ -707,6 +735,117 @@ type Stage struct {
 	Jobs             []string `json:"jobs,omitempty" yaml:"jobs,omitempty"`
 }
 
+// StageInstance holds information of a specific run of a GoCD stage.
+type StageInstance struct {
+	Name                  string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Counter               int64         `json:"counter,omitempty" yaml:"counter,omitempty"`
+	ApprovalType          string        `json:"approval_type,omitempty" yaml:"approval_type,omitempty"`
+	ApprovedBy            string        `json:"approved_by,omitempty" yaml:"approved_by,omitempty"`
+	ScheduledAt           int64         `json:"scheduled_at,omitempty" yaml:"scheduled_at,omitempty"`
+	LastTransitionedTime  int64         `json:"last_transitioned_time,omitempty" yaml:"last_transitioned_time,omitempty"`
+	Result                string        `json:"result,omitempty" yaml:"result,omitempty"`
+	RerunOfCounter        int64         `json:"rerun_of_counter,omitempty" yaml:"rerun_of_counter,omitempty"`
+	FetchMaterials        bool          `json:"fetch_materials,omitempty" yaml:"fetch_materials,omitempty"`
+	CleanWorkingDirectory bool          `json:"clean_working_directory,omitempty" yaml:"clean_working_directory,omitempty"`
+	ArtifactsDeleted      bool          `json:"artifacts_deleted,omitempty" yaml:"artifacts_deleted,omitempty"`
+	PipelineName          string        `json:"pipeline_name,omitempty" yaml:"pipeline_name,omitempty"`
+	PipelineCounter       int64         `json:"pipeline_counter,omitempty" yaml:"pipeline_counter,omitempty"`
+	Jobs                  []JobInstance `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+}
+
+// PipelineRunResult holds the outcome of a pipeline run triggered by TriggerAndWait.
+// Result is Unknown while the run is incomplete, PendingStage is the stage awaiting manual approval, if any,
+// and FailedJobs, the jobs completed without passing as per StageInstance.FailedJobs, are addressed as 'stage/job'.
+type PipelineRunResult struct {
+	Name         string           `json:"name,omitempty" yaml:"name,omitempty"`
+	Counter      int              `json:"counter,omitempty" yaml:"counter,omitempty"`
+	Result       string           `json:"result,omitempty" yaml:"result,omitempty"`
+	PendingStage string           `json:"pending_stage,omitempty" yaml:"pending_stage,omitempty"`
+	Duration     time.Duration    `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Stages       []StageRunResult `json:"stages,omitempty" yaml:"stages,omitempty"`
+	FailedJobs   []string         `json:"failed_jobs,omitempty" yaml:"failed_jobs,omitempty"`
+}
+
+// StageRunResult holds the outcome of a stage of the pipeline run triggered by TriggerAndWait.
+type StageRunResult struct {
+	Name     string         `json:"name,omitempty" yaml:"name,omitempty"`
+	Counter  int64          `json:"counter,omitempty" yaml:"counter,omitempty"`
+	Result   string         `json:"result,omitempty" yaml:"result,omitempty"`
+	Duration time.Duration  `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Jobs     []JobRunResult `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+}
+
+// JobRunResult holds the outcome of a job of the pipeline run triggered by TriggerAndWait.
+type JobRunResult struct {
+	Name     string        `json:"name,omitempty" yaml:"name,omitempty"`
+	State    string        `json:"state,omitempty" yaml:"state,omitempty"`
+	Result   string        `json:"result,omitempty" yaml:"result,omitempty"`
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// StageHistory holds a page of the run history of a GoCD stage.
+// Next and Previous hold the cursors to be passed as 'after' and 'before' to fetch the adjacent pages.
+type StageHistory struct {
+	Stages   []StageInstance `json:"stages,omitempty" yaml:"stages,omitempty"`
+	Next     string          `json:"next,omitempty" yaml:"next,omitempty"`
+	Previous string          `json:"previous,omitempty" yaml:"previous,omitempty"`
+}
+
+// JobObject holds information required to identify a specific run of a GoCD job.
+type JobObject struct {
+	Pipeline         string `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
+	PipelineInstance string `json:"pipeline_instance,omitempty" yaml:"pipeline_instance,omitempty"`
+	Stage            string `json:"stage,omitempty" yaml:"stage,omitempty"`
+	StageCounter     string `json:"stage_counter,omitempty" yaml:"stage_counter,omitempty"`
+	Name             string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// JobHistory holds a page of the run history of a GoCD job.
+// Next and Previous hold the cursors to be passed as 'after' and 'before' to fetch the adjacent pages.
+type JobHistory struct {
+	Jobs     []JobInstance `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+	Next     string        `json:"next,omitempty" yaml:"next,omitempty"`
+	Previous string        `json:"previous,omitempty" yaml:"previous,omitempty"`
+}
+
+// ConsoleLog holds a chunk of the console log of a GoCD job.
+// Offset is the byte offset from which the subsequent chunk of the log should be fetched.
+type ConsoleLog struct {
+	Content []byte `json:"content,omitempty" yaml:"content,omitempty"`
+	Offset  int64  `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+// JobArtifact holds information of a file or a folder uploaded as artifact of a GoCD job.
+// Files holds the contents of the folder and is empty for files.
+type JobArtifact struct {
+	Name  string        `json:"name,omitempty" yaml:"name,omitempty"`
+	URL   string        `json:"url,omitempty" yaml:"url,omitempty"`
+	Type  string        `json:"type,omitempty" yaml:"type,omitempty"`
+	Files []JobArtifact `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+// JobInstance holds information of a specific run of a GoCD job.
+type JobInstance struct {
+	Name                string               `json:"name,omitempty" yaml:"name,omitempty"`
+	State               string               `json:"state,omitempty" yaml:"state,omitempty"`
+	Result              string               `json:"result,omitempty" yaml:"result,omitempty"`
+	ScheduledDate       int64                `json:"scheduled_date,omitempty" yaml:"scheduled_date,omitempty"`
+	Rerun               bool                 `json:"rerun,omitempty" yaml:"rerun,omitempty"`
+	OriginalJobID       int64                `json:"original_job_id,omitempty" yaml:"original_job_id,omitempty"`
+	AgentUUID           string               `json:"agent_uuid,omitempty" yaml:"agent_uuid,omitempty"`
+	PipelineName        string               `json:"pipeline_name,omitempty" yaml:"pipeline_name,omitempty"`
+	PipelineCounter     int64                `json:"pipeline_counter,omitempty" yaml:"pipeline_counter,omitempty"`
+	StageName           string               `json:"stage_name,omitempty" yaml:"stage_name,omitempty"`
+	StageCounter        string               `json:"stage_counter,omitempty" yaml:"stage_counter,omitempty"`
+	JobStateTransitions []JobStateTransition `json:"job_state_transitions,omitempty" yaml:"job_state_transitions,omitempty"`
+}
+
+// JobStateTransition holds the time, in epoch milliseconds, at which the job moved to a state.
+type JobStateTransition struct {
+	State           string `json:"state,omitempty" yaml:"state,omitempty"`
+	StateChangeTime int64  `json:"state_change_time,omitempty" yaml:"state_change_time,omitempty"`
+}
+
 type VSM struct {
 	Pipeline string           `json:"current_pipeline,omitempty" yaml:"current_pipeline,omitempty"`
 	Level    []PipelineLevels `json:"levels,omitempty" yaml:"levels,omitempty"`
@@ -738,9 +877,20 @@ type EntityPermissions struct {
 }
 
 // PipelineTemplateConfig holds pipeline template config.
+// Pipelines is populated only by GetTemplates and holds the names of the pipelines using the template.
 type PipelineTemplateConfig struct {
-	Name   string                `json:"name,omitempty" yaml:"name,omitempty"`
-	Stages []PipelineStageConfig `json:"stages,omitempty" yaml:"stages,omitempty"`
+	Name      string                `json:"name,omitempty" yaml:"name,omitempty"`
+	Stages    []PipelineStageConfig `json:"stages,omitempty" yaml:"stages,omitempty"`
+	Pipelines []string              `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+	ETAG      string                `json:"etag,omitempty" yaml:"etag,omitempty"`
+}
+
+// TemplateAuthorization holds information of the users and roles authorized to administer or view a pipeline template.
+type TemplateAuthorization struct {
+	AllGroupAdminsAreViewUsers bool                `json:"all_group_admins_are_view_users" yaml:"all_group_admins_are_view_users"`
+	Admin                      AuthorizationConfig `json:"admin,omitempty" yaml:"admin,omitempty"`
+	View                       AuthorizationConfig `json:"view,omitempty" yaml:"view,omitempty"`
+	ETAG                       string              `json:"etag,omitempty" yaml:"etag,omitempty"`
 }
 
 // PipelineStageConfig holds information of pipeline stage.
@@ -878,3 +1028,76 @@ type Message struct {
 	Kind string `xml:"kind,attr"`
 	Text string `xml:"text,attr"`
 }
+
+// Dashboard holds the pipeline groups along with the status of their pipelines as shown on the GoCD dashboard.
+type Dashboard struct {
+	Personalization string                   `json:"personalization,omitempty" yaml:"personalization,omitempty"`
+	PipelineGroups  []DashboardPipelineGroup `json:"pipeline_groups,omitempty" yaml:"pipeline_groups,omitempty"`
+}
+
+// DashboardPipelineGroup holds information of a pipeline group and its pipelines present on the GoCD dashboard.
+type DashboardPipelineGroup struct {
+	Name          string              `json:"name,omitempty" yaml:"name,omitempty"`
+	CanAdminister bool                `json:"can_administer,omitempty" yaml:"can_administer,omitempty"`
+	Pipelines     []DashboardPipeline `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+}
+
+// DashboardPipeline holds the status of a pipeline along with its latest instances present on the GoCD dashboard.
+type DashboardPipeline struct {
+	Name                 string `json:"name,omitempty" yaml:"name,omitempty"`
+	LastUpdatedTimestamp int64  `json:"last_updated_timestamp,omitempty" yaml:"last_updated_timestamp,omitempty"`
+	Locked               bool   `json:"locked,omitempty" yaml:"locked,omitempty"`
+	CanPause             bool   `json:"can_pause,omitempty" yaml:"can_pause,omitempty"`
+	CanAdminister        bool   `json:"can_administer,omitempty" yaml:"can_administer,omitempty"`
+	CanUnlock            bool   `json:"can_unlock,omitempty" yaml:"can_unlock,omitempty"`
+	CanOperate           bool   `json:"can_operate,omitempty" yaml:"can_operate,omitempty"`
+	FromConfigRepo       bool   `json:"from_config_repo,omitempty" yaml:"from_config_repo,omitempty"`
+	PauseInfo            struct {
+		Paused      bool   `json:"paused,omitempty" yaml:"paused,omitempty"`
+		PausedBy    string `json:"paused_by,omitempty" yaml:"paused_by,omitempty"`
+		PauseReason string `json:"pause_reason,omitempty" yaml:"pause_reason,omitempty"`
+	} `json:"pause_info,omitempty" yaml:"pause_info,omitempty"`
+	Instances []DashboardPipelineInstance `json:"instances,omitempty" yaml:"instances,omitempty"`
+}
+
+// DashboardPipelineInstance holds information of a recent run of the pipeline present on the GoCD dashboard.
+type DashboardPipelineInstance struct {
+	Label       string           `json:"label,omitempty" yaml:"label,omitempty"`
+	Counter     int              `json:"counter,omitempty" yaml:"counter,omitempty"`
+	TriggeredBy string           `json:"triggered_by,omitempty" yaml:"triggered_by,omitempty"`
+	ScheduledAt string           `json:"scheduled_at,omitempty" yaml:"scheduled_at,omitempty"`
+	Stages      []DashboardStage `json:"stages,omitempty" yaml:"stages,omitempty"`
+}
+
+// DashboardStage holds the status of a stage of the pipeline instance present on the GoCD dashboard.
+type DashboardStage struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Counter     string `json:"counter,omitempty" yaml:"counter,omitempty"`
+	Status      string `json:"status,omitempty" yaml:"status,omitempty"`
+	ApprovedBy  string `json:"approved_by,omitempty" yaml:"approved_by,omitempty"`
+	ScheduledAt string `json:"scheduled_at,omitempty" yaml:"scheduled_at,omitempty"`
+}
+
+// ServerConfigBundle holds the snapshot of the server configuration of GoCD, exported to be imported to another GoCD server.
+// MailServer and Backup are nil when they are not configured in GoCD.
+// SecureValues lists the paths of the values that GoCD encrypts, dropped from the bundle while exporting it.
+type ServerConfigBundle struct {
+	Version              int                 `json:"version" yaml:"version"`
+	SiteURL              SiteURLConfig       `json:"site_url,omitempty" yaml:"site_url,omitempty"`
+	MailServer           *MailServerConfig   `json:"mail_server,omitempty" yaml:"mail_server,omitempty"`
+	ArtifactConfig       ArtifactInfo        `json:"artifact_config,omitempty" yaml:"artifact_config,omitempty"`
+	Backup               *BackupConfig       `json:"backup,omitempty" yaml:"backup,omitempty"`
+	DefaultJobTimeout    int                 `json:"default_job_timeout,omitempty" yaml:"default_job_timeout,omitempty"`
+	SystemAdmins         SystemAdmins        `json:"system_admins,omitempty" yaml:"system_admins,omitempty"`
+	AuthConfigs          []CommonConfig      `json:"auth_configs,omitempty" yaml:"auth_configs,omitempty"`
+	Roles                []Role              `json:"roles,omitempty" yaml:"roles,omitempty"`
+	PluginSettings       []PluginSettings    `json:"plugin_settings,omitempty" yaml:"plugin_settings,omitempty"`
+	SecretConfigs        []CommonConfig      `json:"secret_configs,omitempty" yaml:"secret_configs,omitempty"`
+	ClusterProfiles      []CommonConfig      `json:"cluster_profiles,omitempty" yaml:"cluster_profiles,omitempty"`
+	ElasticAgentProfiles []CommonConfig      `json:"elastic_agent_profiles,omitempty" yaml:"elastic_agent_profiles,omitempty"`
+	ArtifactStores       []CommonConfig      `json:"artifact_stores,omitempty" yaml:"artifact_stores,omitempty"`
+	PackageRepositories  []PackageRepository `json:"package_repositories,omitempty" yaml:"package_repositories,omitempty"`
+	Environments         []Environment       `json:"environments,omitempty" yaml:"environments,omitempty"`
+	ConfigRepos          []ConfigRepo        `json:"config_repos,omitempty" yaml:"config_repos,omitempty"`
+	SecureValues         []string            `json:"secure_values,omitempty" yaml:"secure_values,omitempty"`
+}
@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetUsers() ([]User, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return nil, err
-	}
-
 	var usersObj Users
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -39,14 +33,9 @@ func (conf *client) GetUsers() ([]User, error) {
 }
 
 func (conf *client) GetUser(user string) (User, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return User{}, err
-	}
-
 	var userObj User
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -67,14 +56,9 @@ func (conf *client) GetUser(user string) (User, error) {
 }
 
 func (conf *client) GetCurrentUser() (User, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return User{}, err
-	}
-
 	var userObj User
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionOne,
 		}).
@@ -95,14 +79,9 @@ func (conf *client) GetCurrentUser() (User, error) {
 }
 
 func (conf *client) CreateUser(user User) (User, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return User{}, err
-	}
-
 	var userConfig User
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -125,14 +104,9 @@ func (conf *client) CreateUser(user User) (User, error) {
 }
 
 func (conf *client) UpdateUser(user User) (User, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return User{}, err
-	}
-
 	var userConfig User
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -155,14 +129,9 @@ func (conf *client) UpdateUser(user User) (User, error) {
 }
 
 func (conf *client) UpdateCurrentUser(user User) (User, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return User{}, err
-	}
-
 	var userObj User
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionOne,
 			"Content-Type": ContentJSON,
@@ -185,12 +154,7 @@ func (conf *client) UpdateCurrentUser(user User) (User, error) {
 }
 
 func (conf *client) DeleteUser(user string) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept": HeaderVersionThree,
 		}).
@@ -207,12 +171,7 @@ func (conf *client) DeleteUser(user string) error {
 }
 
 func (conf *client) BulkDeleteUsers(users map[string]interface{}) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
@@ -231,12 +190,7 @@ func (conf *client) BulkDeleteUsers(users map[string]interface{}) error {
 }
 
 func (conf *client) BulkEnableDisableUsers(users map[string]interface{}) error {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return err
-	}
-
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		SetHeaders(map[string]string{
 			"Accept":       HeaderVersionThree,
 			"Content-Type": ContentJSON,
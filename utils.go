@@ -1,5 +1,17 @@
 package gocd
 
+import "net/url"
+
+// pageLinks holds the links to the adjacent pages returned by the paginated APIs of GoCD.
+type pageLinks struct {
+	Next     pageLink `json:"next,omitempty"`
+	Previous pageLink `json:"previous,omitempty"`
+}
+
+type pageLink struct {
+	Href string `json:"href,omitempty"`
+}
+
 func GetSLice(values interface{}) []string {
 	newValue := make([]string, 0)
 	for _, value := range values.([]interface{}) {
@@ -8,3 +20,17 @@ func GetSLice(values interface{}) []string {
 
 	return newValue
 }
+
+// cursor returns the value of the query parameter from the link, which is used as cursor to fetch the page.
+func (link pageLink) cursor(key string) string {
+	if len(link.Href) == 0 {
+		return ""
+	}
+
+	parsedURL, err := url.Parse(link.Href)
+	if err != nil {
+		return ""
+	}
+
+	return parsedURL.Query().Get(key)
+}
@@ -6,19 +6,13 @@ import (
 	"net/http"
 	"path/filepath"
 
-	"github.com/jinzhu/copier"
 	"github.com/nikhilsbhat/gocd-sdk-go/pkg/errors"
 )
 
 func (conf *client) GetPipelineVSM(pipeline, instance string) (VSM, error) {
-	newClient := &client{}
-	if err := copier.CopyWithOption(newClient, conf, copier.Option{IgnoreEmpty: true, DeepCopy: true}); err != nil {
-		return VSM{}, err
-	}
-
 	var vsmObj VSM
 
-	resp, err := newClient.httpClient.R().
+	resp, err := conf.request().
 		Get(filepath.Join(VSMEndpoint, pipeline, fmt.Sprintf("%s.json", instance))) //nolint:perfsprint
 	if err != nil {
 		return vsmObj, &errors.APIError{